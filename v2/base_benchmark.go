@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"sync"
 	"time"
 )
@@ -12,6 +13,13 @@ type BaseBenchmark struct {
 	category    string
 	testFunc    func(int) // 执行具体测试的函数
 	workload    int       // 单个任务的工作量
+	// shareBudget 非空时在多核测试前以并发任务数调用，让各任务分摊内存或磁盘等资源预算，测试后以1调用恢复
+	shareBudget func(tasks int)
+	// calibration 非空时返回本次工作量与参考机校准参数不一致的原因，不为空则不计分
+	calibration func(flags referenceFlags) string
+
+	errMu   sync.Mutex
+	testErr error // testFunc执行中的第一个错误，非nil时本次测试不计分
 }
 
 // NewBaseBenchmark 创建基础基准测试
@@ -44,18 +52,67 @@ func (bb *BaseBenchmark) Category() string {
 func (bb *BaseBenchmark) Run(proc, times int) (res BenchmarkResult) {
 	res.Proc = proc
 	res.Times = times
+	bb.clearError()
+
+	tAll := time.Now()
+	defer func() {
+		res.Duration = time.Since(tAll)
+	}()
+
+	res.SingleDuration = measureSingle(func() { bb.testFunc(bb.workload) })
+	res.MultiDuration = bb.measureMulti(proc, times)
+	bb.fillResult(&res)
+	return
+}
+
+// runWithMetrics 执行基准测试后调用collect补充附加指标，总耗时包含collect的耗时
+func (bb *BaseBenchmark) runWithMetrics(proc, times int, collect func(res *BenchmarkResult)) BenchmarkResult {
+	return withMetrics(func() BenchmarkResult { return bb.Run(proc, times) }, collect)
+}
+
+// withMetrics 执行run后调用collect补充附加指标，总耗时包含collect的耗时
+func withMetrics(run func() BenchmarkResult, collect func(res *BenchmarkResult)) BenchmarkResult {
+	tAll := time.Now()
+	res := run()
+	collect(&res)
+	res.Duration = time.Since(tAll)
+	return res
+}
+
+// benchmarkPhase 分阶段计时的子阶段
+type benchmarkPhase struct {
+	name string
+	run  func()
+}
+
+// runPhases 分别计时每个子阶段并记录为附加指标，单核耗时为各子阶段耗时之和，多核测试运行完整的testFunc
+func (bb *BaseBenchmark) runPhases(proc, times int, phases []benchmarkPhase) (res BenchmarkResult) {
+	res.Proc = proc
+	res.Times = times
+	bb.clearError()
 
 	tAll := time.Now()
 	defer func() {
 		res.Duration = time.Since(tAll)
 	}()
 
-	// 顺序执行单核测试，固定5次，剔除最值后求平均
+	for _, phase := range phases {
+		d := measureSingle(phase.run)
+		res.SingleDuration += d
+		res.Metrics = append(res.Metrics, BenchmarkMetric{Name: phase.name, Value: float64(d.Microseconds()) / 1000, Unit: "ms"})
+	}
+	res.MultiDuration = bb.measureMulti(proc, times)
+	bb.fillResult(&res)
+	return
+}
+
+// measureSingle 顺序执行单核测试，固定5次，剔除最值后求平均
+func measureSingle(fn func()) time.Duration {
 	singleTestCount := 5
 	singleTimes := make([]time.Duration, singleTestCount)
 	for i := 0; i < singleTestCount; i++ {
 		startSingle := time.Now()
-		bb.testFunc(bb.workload)
+		fn()
 		singleTimes[i] = time.Since(startSingle)
 	}
 	maxIndex := 0
@@ -77,10 +134,16 @@ func (bb *BaseBenchmark) Run(proc, times int) (res BenchmarkResult) {
 			averageCount++
 		}
 	}
-	res.SingleDuration = totalSingleTime / time.Duration(averageCount)
+	return totalSingleTime / time.Duration(averageCount)
+}
 
-	// 多核测试
+// measureMulti 多核测试，同时运行proc*times个任务，返回平均每轮的耗时
+func (bb *BaseBenchmark) measureMulti(proc, times int) time.Duration {
 	p := proc * times
+	if bb.shareBudget != nil {
+		bb.shareBudget(p)
+		defer bb.shareBudget(1)
+	}
 	ch := make(chan time.Duration, p)
 	wg := new(sync.WaitGroup)
 	wg.Add(p)
@@ -94,12 +157,82 @@ func (bb *BaseBenchmark) Run(proc, times int) (res BenchmarkResult) {
 		}()
 	}
 	wg.Wait()
-	res.MultiDuration = time.Since(start) / time.Duration(times)
+	multiDuration := time.Since(start) / time.Duration(times)
 	close(ch)
+	return multiDuration
+}
+
+// recordError 记录testFunc执行中的错误，只保留第一个，可被多核测试的多个任务并发调用
+func (bb *BaseBenchmark) recordError(err error) {
+	if err == nil {
+		return
+	}
+	bb.errMu.Lock()
+	defer bb.errMu.Unlock()
+	if bb.testErr == nil {
+		bb.testErr = err
+	}
+}
+
+// testError 返回本次测试记录的第一个错误
+func (bb *BaseBenchmark) testError() error {
+	bb.errMu.Lock()
+	defer bb.errMu.Unlock()
+	return bb.testErr
+}
+
+// clearError 清除上次测试记录的错误
+func (bb *BaseBenchmark) clearError() {
+	bb.errMu.Lock()
+	bb.testErr = nil
+	bb.errMu.Unlock()
+}
+
+// parallelSpeedup 计算多核吞吐加速比和并行效率
+// 多核测试在 multi*times 的总耗时内完成 proc*times 个任务，单核测试在 single 内完成1个任务，
+// 加速比为两者每秒完成任务数之比，并行效率为加速比除以proc
+func parallelSpeedup(single, multi time.Duration, proc int) (speedup, efficiency float64) {
+	if single <= 0 || multi <= 0 || proc < 1 {
+		return 0, 0
+	}
+	speedup = float64(proc) * single.Seconds() / multi.Seconds()
+	return speedup, speedup / float64(proc)
+}
 
+// fillResult 根据单核和多核耗时填充名称、加速比，并按参考机耗时计算得分
+// 多核任务分摊了资源预算（shareBudget）时，单个任务的工作量随proc*times变化：任务数大于1时多核与单核的工作量不同，
+// 不计算加速比和并行效率；任务数与参考机校准时不同时多核得分不可比
+func (bb *BaseBenchmark) fillResult(res *BenchmarkResult) {
 	res.Name = bb.Name()
 	res.Category = bb.Category()
-	res.Ratio = float64(res.MultiDuration / res.SingleDuration)
-	res.Score = 0.8*timeToScore(res.SingleDuration) + 0.2*timeToScore(res.MultiDuration)
-	return
+	res.Ratio, res.Efficiency = parallelSpeedup(res.SingleDuration, res.MultiDuration, res.Proc)
+	if err := bb.testError(); err != nil {
+		// 出错的测试提前返回，耗时不反映实际性能
+		res.Ratio, res.Efficiency = 0, 0
+		skipScoring(res, fmt.Sprintf("测试出错: %v", err))
+		return
+	}
+	tasks := res.Proc * res.Times
+	if bb.shareBudget != nil && tasks > 1 {
+		res.Ratio, res.Efficiency = 0, 0
+		res.Notes = append(res.Notes, fmt.Sprintf("多核测试的 %d 个任务分摊了工作量，与单核测试不同，不计算加速比和并行效率", tasks))
+	}
+	table := getReference()
+	ref, ok := table.Benchmarks[res.Name]
+	if !ok {
+		res.Notes = append(res.Notes, "参考机校准表中没有该测试，不计分")
+		return
+	}
+	if bb.calibration != nil {
+		if reason := bb.calibration(table.Flags); reason != "" {
+			skipScoring(res, reason)
+			return
+		}
+	}
+	res.SingleScore = normalizedScore(ref.SingleMs, res.SingleDuration)
+	res.MultiScore = normalizedScore(ref.MultiMs, perProcDuration(res.MultiDuration, res.Proc))
+	res.Score = 0.8*res.SingleScore + 0.2*res.MultiScore
+	if bb.shareBudget != nil && tasks != table.Flags.tasks() {
+		skipMultiScore(res, fmt.Sprintf("多核任务数 %d 与参考机校准时的 %d（%s）不同", tasks, table.Flags.tasks(), table.Flags))
+	}
 }
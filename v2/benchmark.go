@@ -18,12 +18,24 @@ type BenchmarkResult struct {
 	Name           string
 	Category       string
 	Duration       time.Duration
-	SingleDuration time.Duration // 单核性能指标
-	MultiDuration  time.Duration // 多核性能指标
-	Ratio          float64       // 倍率
-	Score          float64       // 综合得分
-	Proc           int           // 使用的核心数
-	Times          int           // 运行次数
+	SingleDuration time.Duration     // 单核性能指标
+	MultiDuration  time.Duration     // 多核性能指标
+	Ratio          float64           // 多核吞吐加速比
+	Efficiency     float64           // 并行效率（加速比/核心数）
+	SingleScore    float64           // 单核得分
+	MultiScore     float64           // 多核得分
+	Score          float64           // 综合得分（80%单核 + 20%多核）
+	Proc           int               // 使用的核心数
+	Times          int               // 运行次数
+	Metrics        []BenchmarkMetric // 附加指标
+	Notes          []string          // 附加说明（如图表）
+}
+
+// BenchmarkMetric 测试附加指标
+type BenchmarkMetric struct {
+	Name  string
+	Value float64
+	Unit  string
 }
 
 // BenchmarkSuite 测试套件
@@ -31,29 +43,69 @@ type BenchmarkSuite struct {
 	benchmarks []Benchmark
 }
 
+// SuiteOptions 测试套件的可选配置
+type SuiteOptions struct {
+	GCLiveHeapMB  int    // GC测试的存活堆大小（MB）
+	GCSweep       bool   // GC测试是否扫描GOGC和GOMEMLIMIT
+	MatrixMaxSize int    // 矩阵测试规模扫描的最大矩阵大小
+	RenderDir     string // 渲染测试保存PNG的目录，为空则不保存
+	StorageDir    string // 存储测试使用的目录，为空则使用系统临时目录
+	SkipStorage   bool   // 是否跳过存储测试
+	HTTPClients   int    // HTTP测试的并发客户端数，为0则使用proc
+	HTTPTLS       bool   // HTTP测试是否额外运行TLS版本
+}
+
 // NewBenchmarkSuite 创建新的测试套件
-func NewBenchmarkSuite() *BenchmarkSuite {
-	return &BenchmarkSuite{
+func NewBenchmarkSuite(opts SuiteOptions) *BenchmarkSuite {
+	suite := &BenchmarkSuite{
 		benchmarks: []Benchmark{
-			NewComputeBenchmark(),          // 计算密集型测试（Pi计算）
-			NewBitOperationsBenchmark(),    // 位运算测试
-			NewIntegerBenchmark(),          // 整数运算测试
-			NewMemoryBenchmark(),           // 内存访问测试
-			NewMemorySequentialBenchmark(), // 顺序内存访问测试
-			NewConcurrencyBenchmark(),      // 并发处理测试
-			NewChannelBenchmark(),          // 通道通信测试
-			NewCryptoBenchmark(),           // 加密运算测试
-			NewAdvancedCryptoBenchmark(),   // 高级加密测试
-			NewHashBenchmark(),             // 哈希运算测试
-			NewFloatBenchmark(),            // 浮点运算测试
-			NewTrigBenchmark(),             // 三角函数测试
-			NewMatrixBenchmark(),           // 矩阵运算测试
-			NewCompressionBenchmark(),      // 压缩性能测试
-			NewSortingBenchmark(),          // 排序算法测试
-			NewStringBenchmark(),           // 字符串处理测试
-			NewBinaryBenchmark(),           // 二进制处理测试
+			NewComputeBenchmark(),                            // 计算密集型测试（Pi计算）
+			NewBitOperationsBenchmark(),                      // 位运算测试
+			NewIntegerBenchmark(),                            // 整数运算测试
+			NewMicroarchBenchmark(),                          // 微架构测试
+			NewMemoryBenchmark(),                             // 内存访问测试
+			NewMemorySequentialBenchmark(),                   // 顺序内存访问测试
+			NewMemoryLatencyBenchmark(),                      // 内存延迟阶梯测试
+			NewGCBenchmark(opts.GCLiveHeapMB, opts.GCSweep),  // GC压力测试
+			NewConcurrencyBenchmark(),                        // 并发处理测试
+			NewChannelBenchmark(),                            // 通道通信测试
+			NewFalseSharingBenchmark(),                       // 伪共享测试
+			NewSyncPrimitiveBenchmark(),                      // 同步原语对比测试
+			NewSchedulerBenchmark(),                          // 调度器微基准测试
+			NewSyscallBenchmark(),                            // 系统调用和上下文切换测试
+			NewCryptoBenchmark(),                             // 加密运算测试
+			NewAdvancedCryptoBenchmark(),                     // 高级加密测试
+			NewPublicKeyBenchmark(),                          // 公钥加密测试
+			NewHashBenchmark(),                               // 哈希运算测试
+			NewFloatBenchmark(),                              // 浮点运算测试
+			NewTrigBenchmark(),                               // 三角函数测试
+			NewMatrixBenchmark(opts.MatrixMaxSize),           // 矩阵运算测试
+			NewScientificBenchmark(),                         // 科学计算内核测试
+			NewRenderBenchmark(opts.RenderDir),               // 图形渲染测试
+			NewCompressionBenchmark(),                        // 压缩性能测试
+			NewCompressionCorpusBenchmark(),                  // 混合语料压缩测试
+			NewSortingBenchmark(),                            // 排序算法测试
+			NewMapBenchmark(),                                // 哈希表测试
+			NewStringBenchmark(),                             // 字符串处理测试
+			NewTextProcessingBenchmark(),                     // 文本处理测试
+			NewBinaryBenchmark(),                             // 二进制处理测试
+			NewJSONBenchmark(),                               // JSON序列化测试
+			NewGobBenchmark(),                                // Gob序列化测试
+			NewXMLBenchmark(),                                // XML序列化测试
+			NewBase64Benchmark(),                             // Base64编解码测试
+			NewVarintBenchmark(),                             // Varint编解码测试
+			NewPNGBenchmark(),                                // PNG编解码测试
+			NewJPEGBenchmark(),                               // JPEG编解码测试
+			NewGIFBenchmark(),                                // GIF编解码测试
+			NewGaussianBlurBenchmark(),                       // 高斯模糊测试
+			NewNetworkBenchmark(),                            // 回环网络测试
+			NewHTTPBenchmark(opts.HTTPClients, opts.HTTPTLS), // HTTP服务测试
 		},
 	}
+	if !opts.SkipStorage {
+		suite.AddBenchmark(NewStorageBenchmark(opts.StorageDir)) // 文件读写测试
+	}
+	return suite
 }
 
 // AddBenchmark 添加测试项目
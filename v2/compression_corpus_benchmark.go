@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"compress/lzw"
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// corpusSegmentSize 语料中每类数据的大小
+const corpusSegmentSize = 64 * 1024
+
+// corpusBzip2 由bzip2命令行工具压缩generateCorpus()的输出得到，标准库只支持bzip2解压
+//
+//go:embed data/corpus.bz2
+var corpusBzip2 []byte
+
+var (
+	corpusOnce sync.Once
+	corpusData []byte
+)
+
+// getCorpus 返回确定性的混合语料：JSON日志、源代码文本、二进制表格和随机噪声
+func getCorpus() []byte {
+	corpusOnce.Do(func() {
+		corpusData = generateCorpus()
+	})
+	return corpusData
+}
+
+func generateCorpus() []byte {
+	r := rand.New(rand.NewSource(42))
+	var buf bytes.Buffer
+	buf.Write(corpusJSONLogs(r))
+	buf.Write(corpusSourceText(r))
+	buf.Write(corpusBinaryTable(r))
+	noise := make([]byte, corpusSegmentSize)
+	_, _ = r.Read(noise)
+	buf.Write(noise)
+	return buf.Bytes()
+}
+
+func corpusJSONLogs(r *rand.Rand) []byte {
+	levels := []string{"debug", "info", "info", "info", "warn", "error"}
+	paths := []string{"/v1/users", "/v1/orders", "/v1/items", "/healthz", "/v2/search"}
+	messages := []string{"request completed", "cache miss", "upstream timeout", "user not found", "retrying"}
+	var b strings.Builder
+	ts := int64(1700000000000)
+	for b.Len() < corpusSegmentSize {
+		ts += int64(r.Intn(50))
+		fmt.Fprintf(&b, `{"ts":%d,"level":"%s","svc":"api-%d","path":"%s/%d","status":%d,"latency_ms":%.3f,"msg":"%s"}`+"\n",
+			ts, levels[r.Intn(len(levels))], r.Intn(8), paths[r.Intn(len(paths))], r.Intn(100000),
+			[]int{200, 200, 200, 201, 404, 500}[r.Intn(6)], r.ExpFloat64()*20, messages[r.Intn(len(messages))])
+	}
+	return []byte(b.String()[:corpusSegmentSize])
+}
+
+func corpusSourceText(r *rand.Rand) []byte {
+	names := []string{"result", "buffer", "count", "index", "value", "err", "ctx", "node", "key", "total"}
+	types := []string{"int", "string", "[]byte", "error", "float64", "*Node", "map[string]int"}
+	var b strings.Builder
+	for fn := 0; b.Len() < corpusSegmentSize; fn++ {
+		fmt.Fprintf(&b, "// process%d 处理第%d组数据\nfunc process%d(%s %s) (%s, error) {\n",
+			fn, fn, fn, names[r.Intn(len(names))], types[r.Intn(len(types))], types[r.Intn(len(types))])
+		for i := r.Intn(8) + 2; i > 0; i-- {
+			a, c := names[r.Intn(len(names))], names[r.Intn(len(names))]
+			switch r.Intn(3) {
+			case 0:
+				fmt.Fprintf(&b, "\t%s := %s + %d\n", a, c, r.Intn(1000))
+			case 1:
+				fmt.Fprintf(&b, "\tif %s != nil {\n\t\treturn nil, %s\n\t}\n", a, c)
+			default:
+				fmt.Fprintf(&b, "\tfor i := 0; i < len(%s); i++ {\n\t\t%s[i] = %s[i] * %d\n\t}\n", a, a, c, r.Intn(16))
+			}
+		}
+		b.WriteString("\treturn result, nil\n}\n\n")
+	}
+	return []byte(b.String()[:corpusSegmentSize])
+}
+
+func corpusBinaryTable(r *rand.Rand) []byte {
+	buf := make([]byte, corpusSegmentSize)
+	id, price := uint32(1000), 100.0
+	for off := 0; off+16 <= len(buf); off += 16 {
+		id += uint32(r.Intn(3) + 1)
+		price += r.NormFloat64()
+		binary.LittleEndian.PutUint32(buf[off:], id)
+		binary.LittleEndian.PutUint32(buf[off+4:], math.Float32bits(float32(price)))
+		binary.LittleEndian.PutUint32(buf[off+8:], uint32(r.Intn(500)))
+		binary.LittleEndian.PutUint32(buf[off+12:], uint32(off/16%7))
+	}
+	return buf
+}
+
+// corpusCodec 语料压缩测试的编解码器，compress为nil表示只测试解压
+type corpusCodec struct {
+	name       string
+	compress   func(data []byte) []byte
+	decompress func(compressed []byte) []byte
+}
+
+// corpusCodecs 参与测试的编解码器
+var corpusCodecs = []corpusCodec{
+	flateCodec(flate.BestSpeed),
+	flateCodec(flate.DefaultCompression),
+	flateCodec(flate.BestCompression),
+	{
+		name: "lzw",
+		compress: func(data []byte) []byte {
+			var buf bytes.Buffer
+			w := lzw.NewWriter(&buf, lzw.LSB, 8)
+			_, _ = w.Write(data)
+			_ = w.Close()
+			return buf.Bytes()
+		},
+		decompress: func(compressed []byte) []byte {
+			rd := lzw.NewReader(bytes.NewReader(compressed), lzw.LSB, 8)
+			defer rd.Close()
+			out, _ := io.ReadAll(rd)
+			return out
+		},
+	},
+	{
+		name: "bzip2",
+		decompress: func(compressed []byte) []byte {
+			out, _ := io.ReadAll(bzip2.NewReader(bytes.NewReader(compressed)))
+			return out
+		},
+	},
+}
+
+func flateCodec(level int) corpusCodec {
+	if level == flate.DefaultCompression {
+		level = 6
+	}
+	return corpusCodec{
+		name: fmt.Sprintf("flate-%d", level),
+		compress: func(data []byte) []byte {
+			var buf bytes.Buffer
+			w, _ := flate.NewWriter(&buf, level)
+			_, _ = w.Write(data)
+			_ = w.Close()
+			return buf.Bytes()
+		},
+		decompress: func(compressed []byte) []byte {
+			rd := flate.NewReader(bytes.NewReader(compressed))
+			defer rd.Close()
+			out, _ := io.ReadAll(rd)
+			return out
+		},
+	}
+}
+
+// corpusCodecResult 单个编解码器的测试结果
+type corpusCodecResult struct {
+	compressTime   time.Duration
+	decompressTime time.Duration
+	compressedSize int
+}
+
+// runCorpusCodec 压缩并解压语料一次，校验解压结果
+func runCorpusCodec(codec corpusCodec, corpus []byte) (res corpusCodecResult, err error) {
+	compressed := corpusBzip2
+	if codec.compress != nil {
+		start := time.Now()
+		compressed = codec.compress(corpus)
+		res.compressTime = time.Since(start)
+	}
+	res.compressedSize = len(compressed)
+	start := time.Now()
+	out := codec.decompress(compressed)
+	res.decompressTime = time.Since(start)
+	if !bytes.Equal(out, corpus) {
+		err = fmt.Errorf("%s 解压结果与原始语料不一致", codec.name)
+	}
+	return
+}
+
+// CompressionCorpusBenchmark 混合语料压缩测试
+type CompressionCorpusBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewCompressionCorpusBenchmark 创建混合语料压缩测试实例
+func NewCompressionCorpusBenchmark() *CompressionCorpusBenchmark {
+	testFunc := func(workload int) {
+		corpus := getCorpus()
+		for i := 0; i < workload; i++ {
+			for _, codec := range corpusCodecs {
+				_, _ = runCorpusCodec(codec, corpus)
+			}
+		}
+	}
+
+	return &CompressionCorpusBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"混合语料压缩（Compression Corpus）",
+			"使用混合语料测试flate各级别、lzw压缩和bzip2解压性能",
+			"压缩性能",
+			testFunc,
+			3, // 处理语料3轮
+		),
+	}
+}
+
+// Run 执行基准测试，并报告各编解码器的压缩、解压速度和压缩比
+func (b *CompressionCorpusBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		corpus := getCorpus()
+		mb := float64(len(corpus)) / 1024 / 1024
+		for _, codec := range corpusCodecs {
+			r, err := runCorpusCodec(codec, corpus)
+			if err != nil {
+				res.Notes = append(res.Notes, err.Error())
+				continue
+			}
+			if codec.compress != nil {
+				res.Metrics = append(res.Metrics, BenchmarkMetric{Name: codec.name + "/压缩", Value: mb / r.compressTime.Seconds(), Unit: "MB/s"})
+			}
+			res.Metrics = append(res.Metrics,
+				BenchmarkMetric{Name: codec.name + "/解压", Value: mb / r.decompressTime.Seconds(), Unit: "MB/s"},
+				BenchmarkMetric{Name: codec.name + "/压缩比", Value: float64(len(corpus)) / float64(r.compressedSize), Unit: "x"},
+			)
+		}
+		res.Notes = append(res.Notes, fmt.Sprintf("语料大小 %s（JSON日志、源代码、二进制表格、随机噪声各 %s）",
+			formatBytes(len(corpus)), formatBytes(corpusSegmentSize)))
+	})
+}
@@ -1,10 +1,24 @@
 package main
 
 import (
+	"fmt"
 	"sync"
-	"time"
 )
 
+// concurrencyPhases 并发测试的各个子阶段，均执行CPU密集的实际工作，workers为竞争的goroutine数
+var concurrencyPhases = []struct {
+	name string
+	run  func(operations, workers int) uint64
+}{
+	{"Goroutine创建", spawnPhase},
+	{"互斥锁计数", mutexCounterPhase},
+	{"通道传递", channelPhase},
+	{"WaitGroup屏障", waitGroupBarrierPhase},
+}
+
+// concurrencyScoredWorkers 计分测试中互斥锁竞争固定使用的goroutine数，使工作量不随-proc变化，与参考机可比
+const concurrencyScoredWorkers = 2
+
 // ConcurrencyBenchmark 并发处理性能测试
 type ConcurrencyBenchmark struct {
 	*BaseBenchmark
@@ -12,62 +26,139 @@ type ConcurrencyBenchmark struct {
 
 // NewConcurrencyBenchmark 创建并发测试实例
 func NewConcurrencyBenchmark() *ConcurrencyBenchmark {
+	testFunc := func(workload int) {
+		concurrencyTest(workload, concurrencyScoredWorkers)
+	}
+
 	return &ConcurrencyBenchmark{
 		BaseBenchmark: NewBaseBenchmark(
 			"并发测试（Concurrency Test）",
 			"测试并发处理和同步性能",
 			"并发性能",
-			concurrencyTest,
+			testFunc,
 			1000000, // 100万次操作
 		),
 	}
 }
 
-func concurrencyTest(operations int) {
+// Run 分别计时每个子阶段，单核耗时为各子阶段耗时之和，并测量互斥锁在1~proc个goroutine竞争下的耗时
+func (b *ConcurrencyBenchmark) Run(proc, times int) BenchmarkResult {
+	phases := make([]benchmarkPhase, len(concurrencyPhases))
+	for i, phase := range concurrencyPhases {
+		run := phase.run
+		phases[i] = benchmarkPhase{phase.name, func() { _ = run(b.workload, concurrencyScoredWorkers) }}
+	}
+	return withMetrics(func() BenchmarkResult { return b.runPhases(proc, times, phases) }, func(res *BenchmarkResult) {
+		for _, workers := range contentionLevels(proc) {
+			d := measureSingle(func() { _ = mutexCounterPhase(b.workload, workers) })
+			res.Metrics = append(res.Metrics, BenchmarkMetric{
+				Name:  fmt.Sprintf("互斥锁竞争/%d", workers),
+				Value: float64(d.Nanoseconds()) / float64(b.workload),
+				Unit:  "ns/op",
+			})
+		}
+		res.Notes = append(res.Notes, fmt.Sprintf("计分的子阶段固定使用 %d 个goroutine竞争互斥锁", concurrencyScoredWorkers))
+	})
+}
+
+func concurrencyTest(operations, workers int) {
+	sum := uint64(0)
+	for _, phase := range concurrencyPhases {
+		sum += phase.run(operations, workers)
+	}
+	_ = sum
+}
+
+// spinWork 执行固定次数的xorshift迭代，模拟少量CPU计算
+func spinWork(seed uint64, iterations int) uint64 {
+	x := seed | 1
+	for i := 0; i < iterations; i++ {
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+	}
+	return x
+}
+
+// spawnPhase 以10个为一批共创建operations个goroutine，每个执行少量计算后退出
+func spawnPhase(operations, _ int) uint64 {
 	var wg sync.WaitGroup
-	counter := 0
-	mu := sync.Mutex{}
-	concurrentGoroutines := 10
-	// 并发计数器测试
-	for i := 0; i < operations; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			mu.Lock()
-			counter++
-			mu.Unlock()
-		}()
-		if i%concurrentGoroutines == 0 {
-			wg.Wait()
+	results := make([]uint64, 10)
+	for i := 0; i < operations; i += len(results) {
+		wg.Add(len(results))
+		for j := range results {
+			go func(j int) {
+				defer wg.Done()
+				results[j] += spinWork(uint64(i+j), 100)
+			}(j)
 		}
+		wg.Wait()
+	}
+	sum := uint64(0)
+	for _, r := range results {
+		sum += r
+	}
+	return sum
+}
+
+// mutexCounterPhase workers个goroutine竞争同一把锁，在临界区内更新计数器
+func mutexCounterPhase(operations, workers int) uint64 {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	counter := uint64(0)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < operations/workers; i++ {
+				v := spinWork(uint64(w*operations+i), 4)
+				mu.Lock()
+				counter += v
+				mu.Unlock()
+			}
+		}(w)
 	}
 	wg.Wait()
-	// 通道通信测试
-	ch := make(chan int, 100)
-	done := make(chan bool)
+	return counter
+}
+
+// channelPhase 生产者计算后经带缓冲通道发送，消费者接收并累加
+func channelPhase(operations, _ int) uint64 {
+	ch := make(chan uint64, 100)
+	done := make(chan uint64)
 	go func() {
-		for val := range ch {
-			_ = val
+		sum := uint64(0)
+		for v := range ch {
+			sum += spinWork(v, 8)
 		}
-		done <- true
+		done <- sum
 	}()
-	for i := 0; i < 10000; i++ {
-		ch <- i
+	for i := 0; i < operations/10; i++ {
+		ch <- spinWork(uint64(i), 8)
 	}
 	close(ch)
-	<-done
-	// WaitGroup测试
-	for i := 0; i < 100; i++ {
-		wg.Add(10)
-		for j := 0; j < 10; j++ {
-			go func() {
+	return <-done
+}
+
+// waitGroupBarrierPhase 每轮10个goroutine完成计算后在WaitGroup处汇合
+func waitGroupBarrierPhase(operations, _ int) uint64 {
+	var wg sync.WaitGroup
+	results := make([]uint64, 10)
+	for round := 0; round < operations/1000; round++ {
+		wg.Add(len(results))
+		for j := range results {
+			go func(j int) {
 				defer wg.Done()
-				time.Sleep(time.Microsecond)
-			}()
+				results[j] += spinWork(uint64(round*10+j), 1000)
+			}(j)
 		}
 		wg.Wait()
 	}
-	_ = counter
+	sum := uint64(0)
+	for _, r := range results {
+		sum += r
+	}
+	return sum
 }
 
 // ChannelBenchmark 通道通信测试
@@ -3,13 +3,145 @@ package main
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
-	"encoding/hex"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math/bits"
+	"strings"
+	"time"
+
+	"github.com/klauspost/cpuid/v2"
 )
 
+// cryptoMessageSizes 吞吐量测试的消息大小
+var cryptoMessageSizes = []int{64, 1024, 16 * 1024, 1024 * 1024}
+
+// cryptoAlgorithm 对称加密或哈希算法，newProcessor返回处理单条消息的函数
+type cryptoAlgorithm struct {
+	name         string
+	newProcessor func() func(data []byte)
+}
+
+// cryptoAlgorithms 参与吞吐量测试的算法。标准库未提供ChaCha20，使用本文件中的纯Go实现
+var cryptoAlgorithms = []cryptoAlgorithm{
+	{"MD5", func() func([]byte) { return hashProcessor(md5.New()) }},
+	{"SHA-1", func() func([]byte) { return hashProcessor(sha1.New()) }},
+	{"SHA-256", func() func([]byte) { return hashProcessor(sha256.New()) }},
+	{"SHA-512", func() func([]byte) { return hashProcessor(sha512.New()) }},
+	{"HMAC-SHA256", func() func([]byte) { return hashProcessor(hmac.New(sha256.New, cryptoKey(32))) }},
+	{"AES-128-CTR", func() func([]byte) {
+		block, _ := aes.NewCipher(cryptoKey(16))
+		return streamProcessor(cipher.NewCTR(block, make([]byte, aes.BlockSize)))
+	}},
+	{"AES-128-CBC", func() func([]byte) {
+		block, _ := aes.NewCipher(cryptoKey(16))
+		cbc := cipher.NewCBCEncrypter(block, make([]byte, aes.BlockSize))
+		var dst []byte
+		return func(data []byte) {
+			dst = growBuffer(dst, len(data))
+			cbc.CryptBlocks(dst, data)
+		}
+	}},
+	{"AES-128-GCM", func() func([]byte) { return gcmProcessor(16) }},
+	{"AES-256-GCM", func() func([]byte) { return gcmProcessor(32) }},
+	{"ChaCha20", func() func([]byte) {
+		return streamProcessor(newChaCha20(cryptoKey(32), make([]byte, 12), 0))
+	}},
+}
+
+// cryptoKey 生成固定内容的密钥
+func cryptoKey(size int) []byte {
+	key := make([]byte, size)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	return key
+}
+
+// growBuffer 保证缓冲区至少有n字节
+func growBuffer(buf []byte, n int) []byte {
+	if cap(buf) < n {
+		return make([]byte, n)
+	}
+	return buf[:n]
+}
+
+func hashProcessor(h hash.Hash) func([]byte) {
+	sum := make([]byte, 0, h.Size())
+	return func(data []byte) {
+		h.Reset()
+		h.Write(data)
+		sum = h.Sum(sum[:0])
+	}
+}
+
+func streamProcessor(stream cipher.Stream) func([]byte) {
+	var dst []byte
+	return func(data []byte) {
+		dst = growBuffer(dst, len(data))
+		stream.XORKeyStream(dst, data)
+	}
+}
+
+func gcmProcessor(keySize int) func([]byte) {
+	block, _ := aes.NewCipher(cryptoKey(keySize))
+	aead, _ := cipher.NewGCM(block)
+	nonce := make([]byte, aead.NonceSize())
+	var dst []byte
+	return func(data []byte) {
+		dst = aead.Seal(dst[:0], nonce, data, nil)
+	}
+}
+
+// cryptoThroughput 用指定大小的消息处理约totalBytes字节数据，返回处理的字节数和耗时
+func cryptoThroughput(algorithm cryptoAlgorithm, size, totalBytes int) (int, time.Duration) {
+	process := algorithm.newProcessor()
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	messages := totalBytes / size
+	if messages < 1 {
+		messages = 1
+	}
+	// 预热一次，避免把输出缓冲区的分配计入耗时
+	process(data)
+	start := time.Now()
+	for i := 0; i < messages; i++ {
+		process(data)
+	}
+	return messages * size, time.Since(start)
+}
+
+// cryptoAcceleration 返回加密相关硬件加速指令的支持情况
+func cryptoAcceleration() string {
+	features := []struct {
+		name string
+		id   cpuid.FeatureID
+	}{
+		{"AESNI", cpuid.AESNI},
+		{"SHA", cpuid.SHA},
+		{"PCLMULQDQ", cpuid.CLMUL},
+		{"VAES", cpuid.VAES},
+		{"VPCLMULQDQ", cpuid.VPCLMULQDQ},
+	}
+	parts := make([]string, 0, len(features))
+	for _, f := range features {
+		supported := "否"
+		if cpuid.CPU.Supports(f.id) {
+			supported = "是"
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", f.name, supported))
+	}
+	return "硬件加速: " + strings.Join(parts, " ")
+}
+
 // CryptoBenchmark 加密运算性能测试
 type CryptoBenchmark struct {
 	*BaseBenchmark
@@ -24,40 +156,43 @@ func NewCryptoBenchmark() *CryptoBenchmark {
 	return &CryptoBenchmark{
 		BaseBenchmark: NewBaseBenchmark(
 			"加密算法测试（Cryptography）",
-			"测试哈希和加密算法性能",
+			"测试哈希和加密算法在不同消息大小下的吞吐量",
 			"加密性能",
 			testFunc,
-			200000, // 20万次操作
+			200000, // 相当于20万次1KB消息
 		),
 	}
 }
 
+// Run 执行基准测试，并测量每种算法在各消息大小下的吞吐量
+func (b *CryptoBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		totalBytes := cryptoBytesPerCase(b.workload)
+		for _, algorithm := range cryptoAlgorithms {
+			for _, size := range cryptoMessageSizes {
+				n, d := cryptoThroughput(algorithm, size, totalBytes)
+				res.Metrics = append(res.Metrics, BenchmarkMetric{
+					Name:  fmt.Sprintf("%s/%s", algorithm.name, formatBytes(size)),
+					Value: float64(n) / 1024 / 1024 / d.Seconds(),
+					Unit:  "MB/s",
+				})
+			}
+		}
+		res.Notes = append(res.Notes, cryptoAcceleration())
+	})
+}
+
+// cryptoBytesPerCase 将总工作量（按1KB消息计）平分到每个算法和消息大小
+func cryptoBytesPerCase(operations int) int {
+	return operations * 1024 / len(cryptoAlgorithms) / len(cryptoMessageSizes)
+}
+
 func cryptoTest(operations int) {
-	data := make([]byte, 1024)
-	for i := range data {
-		data[i] = byte(i)
-	}
-	// MD5哈希测试
-	for i := 0; i < operations/4; i++ {
-		hash := md5.Sum(data)
-		_ = hex.EncodeToString(hash[:])
-	}
-	// SHA256哈希测试
-	for i := 0; i < operations/4; i++ {
-		hash := sha256.Sum256(data)
-		_ = hex.EncodeToString(hash[:])
-	}
-	// SHA1哈希测试
-	for i := 0; i < operations/4; i++ {
-		hash := sha1.Sum(data)
-		_ = hex.EncodeToString(hash[:])
-	}
-	// AES加密测试
-	block, _ := aes.NewCipher([]byte("1234567890123456"))
-	stream := cipher.NewCTR(block, make([]byte, aes.BlockSize))
-	encrypted := make([]byte, len(data))
-	for i := 0; i < operations/4; i++ {
-		stream.XORKeyStream(encrypted, data)
+	totalBytes := cryptoBytesPerCase(operations)
+	for _, algorithm := range cryptoAlgorithms {
+		for _, size := range cryptoMessageSizes {
+			_, _ = cryptoThroughput(algorithm, size, totalBytes)
+		}
 	}
 }
 
@@ -95,6 +230,78 @@ func hashTest(operations int) {
 		_ = sha256.Sum256(data)
 	}
 	for i := 0; i < operations/4; i++ {
-		_ = sha256.Sum256(data)
+		_ = sha512.Sum512(data)
+	}
+}
+
+// chacha20Stream RFC 8439定义的ChaCha20流密码，只由32位加法、循环移位和异或（ARX）构成
+type chacha20Stream struct {
+	state [16]uint32
+	block [64]byte // 当前密钥流块
+	used  int      // block中已使用的字节数
+}
+
+// newChaCha20 用32字节密钥、12字节nonce和初始块计数器创建ChaCha20流
+func newChaCha20(key, nonce []byte, counter uint32) *chacha20Stream {
+	s := &chacha20Stream{used: 64}
+	s.state[0], s.state[1], s.state[2], s.state[3] = 0x61707865, 0x3320646e, 0x79622d32, 0x6b206574
+	for i := 0; i < 8; i++ {
+		s.state[4+i] = binary.LittleEndian.Uint32(key[4*i:])
+	}
+	s.state[12] = counter
+	for i := 0; i < 3; i++ {
+		s.state[13+i] = binary.LittleEndian.Uint32(nonce[4*i:])
+	}
+	return s
+}
+
+func chachaQuarterRound(a, b, c, d uint32) (uint32, uint32, uint32, uint32) {
+	a += b
+	d = bits.RotateLeft32(d^a, 16)
+	c += d
+	b = bits.RotateLeft32(b^c, 12)
+	a += b
+	d = bits.RotateLeft32(d^a, 8)
+	c += d
+	b = bits.RotateLeft32(b^c, 7)
+	return a, b, c, d
+}
+
+// refill 执行20轮（10次列轮和对角轮）生成下一个64字节密钥流块
+func (s *chacha20Stream) refill() {
+	x := s.state
+	for i := 0; i < 10; i++ {
+		x[0], x[4], x[8], x[12] = chachaQuarterRound(x[0], x[4], x[8], x[12])
+		x[1], x[5], x[9], x[13] = chachaQuarterRound(x[1], x[5], x[9], x[13])
+		x[2], x[6], x[10], x[14] = chachaQuarterRound(x[2], x[6], x[10], x[14])
+		x[3], x[7], x[11], x[15] = chachaQuarterRound(x[3], x[7], x[11], x[15])
+		x[0], x[5], x[10], x[15] = chachaQuarterRound(x[0], x[5], x[10], x[15])
+		x[1], x[6], x[11], x[12] = chachaQuarterRound(x[1], x[6], x[11], x[12])
+		x[2], x[7], x[8], x[13] = chachaQuarterRound(x[2], x[7], x[8], x[13])
+		x[3], x[4], x[9], x[14] = chachaQuarterRound(x[3], x[4], x[9], x[14])
+	}
+	for i := range x {
+		binary.LittleEndian.PutUint32(s.block[4*i:], x[i]+s.state[i])
+	}
+	s.state[12]++
+	s.used = 0
+}
+
+// XORKeyStream 实现cipher.Stream接口
+func (s *chacha20Stream) XORKeyStream(dst, src []byte) {
+	for len(src) > 0 {
+		if s.used == len(s.block) {
+			s.refill()
+		}
+		keystream := s.block[s.used:]
+		if len(keystream) > len(src) {
+			keystream = keystream[:len(src)]
+		}
+		for i, k := range keystream {
+			dst[i] = src[i] ^ k
+		}
+		s.used += len(keystream)
+		src = src[len(keystream):]
+		dst = dst[len(keystream):]
 	}
 }
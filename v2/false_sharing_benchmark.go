@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// falseSharingModes 伪共享测试的四种组合
+var falseSharingModes = []struct {
+	name   string
+	padded bool
+	atomic bool
+}{
+	{"同一缓存行/普通写", false, false},
+	{"缓存行填充/普通写", true, false},
+	{"同一缓存行/原子操作", false, true},
+	{"缓存行填充/原子操作", true, true},
+}
+
+// falseSharingScoredWorkers 计分测试固定使用的worker数，使工作量不随-proc变化，与参考机可比
+const falseSharingScoredWorkers = 2
+
+// FalseSharingBenchmark 伪共享与缓存行争用测试
+type FalseSharingBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewFalseSharingBenchmark 创建伪共享测试实例
+func NewFalseSharingBenchmark() *FalseSharingBenchmark {
+	testFunc := func(workload int) {
+		for _, mode := range falseSharingModes {
+			falseSharingTest(falseSharingScoredWorkers, workload/len(falseSharingModes), mode.padded, mode.atomic)
+		}
+	}
+
+	return &FalseSharingBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"伪共享测试（False Sharing）",
+			"测试多核写入同一缓存行与填充隔离时的性能差异",
+			"并发性能",
+			testFunc,
+			4000000, // 每个worker 400万次自增
+		),
+	}
+}
+
+// Run 执行基准测试，并以随-proc变化的worker数测量各组合的单次自增耗时及伪共享带来的减速倍数
+func (b *FalseSharingBenchmark) Run(proc, times int) BenchmarkResult {
+	// 同一缓存行最多容纳 cacheLineSize()/8 个计数器，更多worker会分散到多个缓存行
+	workers := minInt(proc, cacheLineSize()/8)
+	if workers < 2 {
+		workers = 2
+	}
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		increments := b.workload / len(falseSharingModes)
+		costs := make([]float64, len(falseSharingModes))
+		for i, mode := range falseSharingModes {
+			d := falseSharingTest(workers, increments, mode.padded, mode.atomic)
+			costs[i] = float64(d.Nanoseconds()) / float64(increments)
+			res.Metrics = append(res.Metrics, BenchmarkMetric{Name: mode.name, Value: costs[i], Unit: "ns/op"})
+		}
+		res.Metrics = append(res.Metrics,
+			BenchmarkMetric{Name: "普通写减速倍数", Value: costs[0] / costs[1], Unit: "x"},
+			BenchmarkMetric{Name: "原子操作减速倍数", Value: costs[2] / costs[3], Unit: "x"},
+		)
+		res.Notes = append(res.Notes, fmt.Sprintf("附加指标使用 %d 个 worker，缓存行 %d 字节（worker数不超过一个缓存行容纳的 %d 个计数器），计分固定使用 %d 个",
+			workers, cacheLineSize(), cacheLineSize()/8, falseSharingScoredWorkers))
+	})
+}
+
+// falseSharingTest 多个worker并发自增各自的计数器，返回总耗时
+func falseSharingTest(workers, increments int, padded, useAtomic bool) time.Duration {
+	stride := 1
+	if padded {
+		stride = cacheLineSize() / 8
+	}
+	counters := alignedCounters(workers*stride + cacheLineSize()/8)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	start := time.Now()
+	for w := 0; w < workers; w++ {
+		counter := &counters[w*stride]
+		go func() {
+			defer wg.Done()
+			if useAtomic {
+				for i := 0; i < increments; i++ {
+					atomic.AddUint64(counter, 1)
+				}
+				return
+			}
+			for i := 0; i < increments; i++ {
+				*counter++
+			}
+		}()
+	}
+	wg.Wait()
+	return time.Since(start)
+}
+
+// alignedCounters 分配起始地址按缓存行对齐的计数器数组
+func alignedCounters(n int) []uint64 {
+	lineSize := cacheLineSize()
+	buf := make([]uint64, n+lineSize/8)
+	offset := 0
+	if rem := int(uintptr(unsafe.Pointer(&buf[0])) % uintptr(lineSize)); rem != 0 {
+		offset = (lineSize - rem) / 8
+	}
+	return buf[offset : offset+n]
+}
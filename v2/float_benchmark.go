@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
+	"sync"
+	"time"
 )
 
 // FloatBenchmark 浮点运算性能测试
@@ -81,64 +84,174 @@ func floatTest(operations int) {
 	_ = result
 }
 
+// matrixSizes 矩阵规模测试的候选大小
+var matrixSizes = []int{256, 512, 1024, 2048}
+
+// matrixNaiveMaxSize 朴素算法在大矩阵上会严重抖动缓存，只测到此大小
+const matrixNaiveMaxSize = 512
+
+// matrixBlockSize 分块算法的块大小
+const matrixBlockSize = 64
+
+// matrixKernel 矩阵乘法实现，计算c = a × b，矩阵均为n×n行主序扁平切片
+type matrixKernel struct {
+	name string
+	run  func(a, b, c []float64, n, proc int)
+}
+
+var matrixKernels = []matrixKernel{
+	{"朴素", func(a, b, c []float64, n, _ int) { matMulNaive(a, b, c, n) }},
+	{"转置", func(a, b, c []float64, n, _ int) { matMulTransposed(a, b, c, n) }},
+	{"分块", func(a, b, c []float64, n, _ int) { matMulBlocked(a, b, c, n, 0, n) }},
+	{"分块并行", matMulParallel},
+}
+
 // MatrixBenchmark 矩阵运算测试
 type MatrixBenchmark struct {
 	*BaseBenchmark
+	maxSize int
 }
 
-func NewMatrixBenchmark() *MatrixBenchmark {
+// NewMatrixBenchmark 创建矩阵运算测试实例，maxSize为规模测试的最大矩阵大小
+func NewMatrixBenchmark(maxSize int) *MatrixBenchmark {
+	if maxSize <= 0 {
+		maxSize = 1024
+	}
 	testFunc := func(workload int) {
-		// 将workload映射为矩阵大小，使工作量合理
-		size := 200
-		if workload > 200 {
-			// 工作量增加时，增大矩阵大小（立方根关系，因为矩阵乘法是O(n³)）
-			ratio := float64(workload) / 200.0
-			size = int(200.0 * pow(ratio, 1.0/3.0))
-		}
-		matrixTest(size)
+		matrixTest(workload)
 	}
 
 	return &MatrixBenchmark{
 		BaseBenchmark: NewBaseBenchmark(
 			"矩阵运算测试（Matrix Operations）",
-			"测试矩阵运算性能",
+			"测试朴素、转置和分块矩阵乘法性能",
 			"浮点性能",
 			testFunc,
-			200, // 基础矩阵大小200x200
+			384, // 基础矩阵大小384x384
 		),
+		maxSize: maxSize,
 	}
 }
 
-// 简单的pow函数实现，避免引入math包
-func pow(x, n float64) float64 {
-	if n == 0 {
-		return 1
-	}
-	result := 1.0
-	for i := 0; i < int(n); i++ {
-		result *= x
+// Run 执行基准测试，并测量各算法在不同矩阵大小下的GFLOPS
+func (b *MatrixBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		for _, n := range matrixSizes {
+			if n > b.maxSize {
+				break
+			}
+			a, bm, c := newMatrices(n)
+			for _, kernel := range matrixKernels {
+				if kernel.name == "朴素" && n > matrixNaiveMaxSize {
+					continue
+				}
+				for i := range c {
+					c[i] = 0
+				}
+				start := time.Now()
+				kernel.run(a, bm, c, n, proc)
+				gflops := 2 * float64(n) * float64(n) * float64(n) / time.Since(start).Seconds() / 1e9
+				res.Metrics = append(res.Metrics, BenchmarkMetric{Name: fmt.Sprintf("%s/%d", kernel.name, n), Value: gflops, Unit: "GFLOPS"})
+			}
+		}
+		res.Notes = append(res.Notes, fmt.Sprintf("分块大小 %d，分块并行使用 %d 个goroutine", matrixBlockSize, proc))
+	})
+}
+
+// newMatrices 创建n×n的输入矩阵a、b和结果矩阵c
+func newMatrices(n int) (a, b, c []float64) {
+	a = make([]float64, n*n)
+	b = make([]float64, n*n)
+	c = make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a[i*n+j] = float64(i*j%7) + 0.1
+			b[i*n+j] = float64((i+j)%5) + 0.2
+		}
 	}
-	return result
+	return
 }
 
 func matrixTest(size int) {
-	a := make([][]float64, size)
-	b := make([][]float64, size)
-	c := make([][]float64, size)
-	for i := 0; i < size; i++ {
-		a[i] = make([]float64, size)
-		b[i] = make([]float64, size)
-		c[i] = make([]float64, size)
-		for j := 0; j < size; j++ {
-			a[i][j] = float64(i*j) + 0.1
-			b[i][j] = float64(i+j) + 0.2
+	a, b, c := newMatrices(size)
+	for _, kernel := range matrixKernels[:3] {
+		for i := range c {
+			c[i] = 0
+		}
+		kernel.run(a, b, c, size, 1)
+	}
+}
+
+// matMulNaive 经典三重循环，内层按列访问b
+func matMulNaive(a, b, c []float64, n int) {
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for k := 0; k < n; k++ {
+				sum += a[i*n+k] * b[k*n+j]
+			}
+			c[i*n+j] = sum
 		}
 	}
-	for i := 0; i < size; i++ {
-		for j := 0; j < size; j++ {
-			for k := 0; k < size; k++ {
-				c[i][j] += a[i][k] * b[k][j]
+}
+
+// matMulTransposed 先转置b，使内层循环按行连续访问
+func matMulTransposed(a, b, c []float64, n int) {
+	bt := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			bt[j*n+i] = b[i*n+j]
+		}
+	}
+	for i := 0; i < n; i++ {
+		rowA := a[i*n : (i+1)*n]
+		for j := 0; j < n; j++ {
+			rowB := bt[j*n : (j+1)*n]
+			sum := 0.0
+			for k, v := range rowA {
+				sum += v * rowB[k]
 			}
+			c[i*n+j] = sum
 		}
 	}
 }
+
+// matMulBlocked 按块计算c的第rowStart到rowEnd行，i-k-j顺序使内层连续访问
+func matMulBlocked(a, b, c []float64, n, rowStart, rowEnd int) {
+	for ii := rowStart; ii < rowEnd; ii += matrixBlockSize {
+		iEnd := minInt(ii+matrixBlockSize, rowEnd)
+		for kk := 0; kk < n; kk += matrixBlockSize {
+			kEnd := minInt(kk+matrixBlockSize, n)
+			for jj := 0; jj < n; jj += matrixBlockSize {
+				jEnd := minInt(jj+matrixBlockSize, n)
+				for i := ii; i < iEnd; i++ {
+					rowC := c[i*n+jj : i*n+jEnd]
+					for k := kk; k < kEnd; k++ {
+						aik := a[i*n+k]
+						rowB := b[k*n+jj : k*n+jEnd]
+						for j, v := range rowB {
+							rowC[j] += aik * v
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// matMulParallel 将行按块分给proc个goroutine并行执行分块算法
+func matMulParallel(a, b, c []float64, n, proc int) {
+	if proc < 1 {
+		proc = 1
+	}
+	rowsPerWorker := (n + proc - 1) / proc
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += rowsPerWorker {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			matMulBlocked(a, b, c, n, start, minInt(start+rowsPerWorker, n))
+		}(start)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"time"
+)
+
+// gcTreeDepth 每棵二叉树的深度，约2047个节点
+const gcTreeDepth = 10
+
+// gcListLength 每条链表的长度
+const gcListLength = 2048
+
+// gcNode 二叉树节点，带少量负载
+type gcNode struct {
+	left, right *gcNode
+	payload     [4]int64
+}
+
+// gcListNode 链表节点
+type gcListNode struct {
+	next    *gcListNode
+	value   *int64
+	payload [2]int64
+}
+
+// gcGraph 由二叉树和链表组成的存活对象图
+type gcGraph struct {
+	trees []*gcNode
+	lists []*gcListNode
+	r     *rand.Rand
+}
+
+func buildGCTree(depth int, seed int64) *gcNode {
+	n := &gcNode{payload: [4]int64{seed, seed + 1, seed + 2, seed + 3}}
+	if depth > 0 {
+		n.left = buildGCTree(depth-1, seed*2)
+		n.right = buildGCTree(depth-1, seed*2+1)
+	}
+	return n
+}
+
+func buildGCList(length int, seed int64) *gcListNode {
+	var head *gcListNode
+	for i := 0; i < length; i++ {
+		v := seed + int64(i)
+		head = &gcListNode{next: head, value: &v, payload: [2]int64{v, -v}}
+	}
+	return head
+}
+
+// newGCGraph 构建约liveBytes字节的存活对象图，树和链表各占一半
+func newGCGraph(liveBytes int) *gcGraph {
+	treeBytes := ((1 << (gcTreeDepth + 1)) - 1) * 48
+	listBytes := gcListLength * (32 + 8)
+	g := &gcGraph{r: rand.New(rand.NewSource(42))}
+	for i := 0; i < liveBytes/2/treeBytes+1; i++ {
+		g.trees = append(g.trees, buildGCTree(gcTreeDepth, int64(i)))
+	}
+	for i := 0; i < liveBytes/2/listBytes+1; i++ {
+		g.lists = append(g.lists, buildGCList(gcListLength, int64(i)))
+	}
+	return g
+}
+
+// mutate 随机替换一棵子树或一段链表，旧对象成为垃圾
+func (g *gcGraph) mutate(steps int) {
+	for i := 0; i < steps; i++ {
+		if i%2 == 0 {
+			tree := g.trees[g.r.Intn(len(g.trees))]
+			depth := g.r.Intn(gcTreeDepth) + 1
+			node := tree
+			for d := gcTreeDepth; d > depth; d-- {
+				if g.r.Intn(2) == 0 {
+					node = node.left
+				} else {
+					node = node.right
+				}
+			}
+			node.left = buildGCTree(depth-1, int64(i))
+		} else {
+			idx := g.r.Intn(len(g.lists))
+			head := g.lists[idx]
+			// 保留前半段，重建后半段
+			node := head
+			for n := 0; n < gcListLength/2; n++ {
+				node = node.next
+			}
+			node.next = buildGCList(gcListLength/2, int64(i))
+		}
+	}
+}
+
+// gcStats 一次GC测试期间的运行时指标
+type gcStats struct {
+	duration    time.Duration
+	allocBytes  float64
+	allocObject float64
+	gcCycles    float64
+	gcCPU       float64 // GC占用的CPU比例
+	pauses      *metrics.Float64Histogram
+}
+
+// gcPauseMetric 优先使用新版运行时的GC暂停指标
+func gcPauseMetric() string {
+	for _, desc := range metrics.All() {
+		if desc.Name == "/sched/pauses/total/gc:seconds" {
+			return desc.Name
+		}
+	}
+	return "/gc/pauses:seconds"
+}
+
+// measureGC 执行fn并统计期间的分配量、GC次数、GC CPU占比和暂停分布
+func measureGC(fn func()) gcStats {
+	samples := []metrics.Sample{
+		{Name: "/gc/heap/allocs:bytes"},
+		{Name: "/gc/heap/allocs:objects"},
+		{Name: "/gc/cycles/total:gc-cycles"},
+		{Name: "/cpu/classes/gc/total:cpu-seconds"},
+		{Name: "/cpu/classes/total:cpu-seconds"},
+		{Name: gcPauseMetric()},
+	}
+	before := make([]metrics.Sample, len(samples))
+	copy(before, samples)
+	metrics.Read(before)
+	start := time.Now()
+	fn()
+	duration := time.Since(start)
+	after := make([]metrics.Sample, len(samples))
+	copy(after, samples)
+	metrics.Read(after)
+
+	delta := func(i int) float64 {
+		if before[i].Value.Kind() == metrics.KindUint64 {
+			return float64(after[i].Value.Uint64() - before[i].Value.Uint64())
+		}
+		if before[i].Value.Kind() == metrics.KindFloat64 {
+			return after[i].Value.Float64() - before[i].Value.Float64()
+		}
+		return 0
+	}
+	stats := gcStats{
+		duration:    duration,
+		allocBytes:  delta(0),
+		allocObject: delta(1),
+		gcCycles:    delta(2),
+	}
+	if total := delta(4); total > 0 {
+		stats.gcCPU = delta(3) / total
+	}
+	if before[5].Value.Kind() == metrics.KindFloat64Histogram {
+		stats.pauses = histogramDelta(before[5].Value.Float64Histogram(), after[5].Value.Float64Histogram())
+	}
+	return stats
+}
+
+// histogramDelta 计算两次累计直方图采样之间的增量
+func histogramDelta(before, after *metrics.Float64Histogram) *metrics.Float64Histogram {
+	counts := make([]uint64, len(after.Counts))
+	for i := range counts {
+		counts[i] = after.Counts[i] - before.Counts[i]
+	}
+	return &metrics.Float64Histogram{Counts: counts, Buckets: after.Buckets}
+}
+
+// histogramPercentile 返回直方图的p分位数（取所在桶的上界）
+func histogramPercentile(h *metrics.Float64Histogram, p float64) float64 {
+	total := uint64(0)
+	for _, c := range h.Counts {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(p * float64(total)))
+	seen := uint64(0)
+	for i, c := range h.Counts {
+		seen += c
+		if seen >= rank {
+			upper := h.Buckets[i+1]
+			if math.IsInf(upper, 1) {
+				upper = h.Buckets[i]
+			}
+			return upper
+		}
+	}
+	return h.Buckets[len(h.Buckets)-1]
+}
+
+// GCBenchmark 垃圾回收压力测试
+type GCBenchmark struct {
+	*BaseBenchmark
+	liveBytes int
+	taskBytes int // 单个任务构建的存活堆大小，多核测试时由各任务分摊liveBytes
+	sweep     bool
+}
+
+// defaultGCHeapMB 默认的存活堆大小，参考机校准表以此生成
+const defaultGCHeapMB = 32
+
+// gcMinTaskBytes 多核测试时单个任务的最小存活堆，避免对象图过小而退化为纯分配测试
+const gcMinTaskBytes = 2 * 1024 * 1024
+
+// NewGCBenchmark 创建GC压力测试实例，liveHeapMB为存活堆大小，sweep为true时扫描GOGC和GOMEMLIMIT
+func NewGCBenchmark(liveHeapMB int, sweep bool) *GCBenchmark {
+	if liveHeapMB <= 0 {
+		liveHeapMB = defaultGCHeapMB
+	}
+	b := &GCBenchmark{liveBytes: liveHeapMB * 1024 * 1024, sweep: sweep}
+	b.taskBytes = b.liveBytes
+	testFunc := func(workload int) {
+		newGCGraph(b.taskBytes).mutate(workload)
+	}
+	b.BaseBenchmark = NewBaseBenchmark(
+		"GC压力测试（Garbage Collector）",
+		"构建并修改大型指针对象图，测试分配吞吐、GC CPU占比和暂停时间",
+		"内存性能",
+		testFunc,
+		2000, // 2000次子树或链表替换
+	)
+	// 多核测试的并发任务分摊同一份存活堆预算，使进程总存活堆仍约为liveBytes
+	b.shareBudget = func(tasks int) {
+		b.taskBytes = b.taskBytesFor(tasks)
+	}
+	// 存活堆大小决定标记工作量，与校准时不同则得分没有可比性
+	b.calibration = func(flags referenceFlags) string {
+		if b.liveBytes != flags.GCHeapMB*1024*1024 {
+			return fmt.Sprintf("存活堆与参考机校准时的 %dMB 不同", flags.GCHeapMB)
+		}
+		return ""
+	}
+	return b
+}
+
+// Run 执行基准测试，并通过runtime/metrics统计GC指标，可选扫描不同的GOGC和内存上限
+func (b *GCBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		res.Metrics = append(res.Metrics, b.measure("")...)
+		if b.sweep {
+			for _, gogc := range []int{50, 100, 200, 400} {
+				old := debug.SetGCPercent(gogc)
+				res.Metrics = append(res.Metrics, b.measure(fmt.Sprintf("GOGC=%d/", gogc))...)
+				debug.SetGCPercent(old)
+			}
+			// 关闭GOGC，只依靠内存上限触发GC
+			limit := int64(b.liveBytes) * 2
+			oldGC := debug.SetGCPercent(-1)
+			oldLimit := debug.SetMemoryLimit(limit)
+			res.Metrics = append(res.Metrics, b.measure(fmt.Sprintf("GOMEMLIMIT=%s/", formatBytes(int(limit))))...)
+			debug.SetMemoryLimit(oldLimit)
+			debug.SetGCPercent(oldGC)
+		}
+		res.Notes = append(res.Notes, fmt.Sprintf("存活堆约 %s，多核测试每个任务 %s", formatBytes(b.liveBytes), formatBytes(b.taskBytesFor(proc*times))))
+	})
+}
+
+// taskBytesFor 返回tasks个并发任务分摊liveBytes后单个任务的存活堆大小
+func (b *GCBenchmark) taskBytesFor(tasks int) int {
+	n := b.liveBytes / tasks
+	if n < gcMinTaskBytes {
+		n = gcMinTaskBytes
+	}
+	if n > b.liveBytes {
+		n = b.liveBytes
+	}
+	return n
+}
+
+// measure 在当前GC设置下运行一次测试并生成指标，prefix用于区分扫描配置
+func (b *GCBenchmark) measure(prefix string) []BenchmarkMetric {
+	runtime.GC()
+	stats := measureGC(func() {
+		newGCGraph(b.liveBytes).mutate(b.workload)
+	})
+	seconds := stats.duration.Seconds()
+	metrics := []BenchmarkMetric{
+		{Name: prefix + "分配吞吐", Value: stats.allocBytes / 1024 / 1024 / seconds, Unit: "MB/s"},
+		{Name: prefix + "分配对象", Value: stats.allocObject / seconds / 1e6, Unit: "M objects/s"},
+		{Name: prefix + "GC次数", Value: stats.gcCycles, Unit: "次"},
+		{Name: prefix + "GC CPU占比", Value: stats.gcCPU * 100, Unit: "%"},
+	}
+	if stats.pauses != nil {
+		for _, p := range []struct {
+			name string
+			q    float64
+		}{{"p50", 0.50}, {"p90", 0.90}, {"p99", 0.99}, {"max", 1}} {
+			metrics = append(metrics, BenchmarkMetric{
+				Name:  prefix + "GC暂停/" + p.name,
+				Value: histogramPercentile(stats.pauses, p.q) * 1e6,
+				Unit:  "µs",
+			})
+		}
+	}
+	return metrics
+}
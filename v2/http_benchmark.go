@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// httpOrderResponse 创建订单接口的应答
+type httpOrderResponse struct {
+	ID    int64   `json:"id"`
+	Items int     `json:"items"`
+	Total float64 `json:"total"`
+}
+
+// newHTTPHandler 返回测试用的JSON接口：GET /orders/{n} 查询订单，POST /orders 提交订单
+func newHTTPHandler() http.Handler {
+	doc := newSerialDocument()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orders/", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(r.URL.Path[len("/orders/"):])
+		if err != nil || n < 0 {
+			http.Error(w, "bad order id", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&doc.Orders[n%len(doc.Orders)])
+	})
+	mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var order serialOrder
+		if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := httpOrderResponse{ID: order.ID, Items: len(order.Items)}
+		for _, item := range order.Items {
+			resp.Total += item.Price * float64(item.Quantity)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&resp)
+	})
+	return mux
+}
+
+// startHTTPServer 在127.0.0.1上启动HTTP/1.1服务器，返回服务器和支持clients条长连接的客户端
+func startHTTPServer(useTLS bool, clients int) (*httptest.Server, *http.Client) {
+	server := httptest.NewUnstartedServer(newHTTPHandler())
+	// 关闭服务器时未完成的握手会记录错误日志，测试中忽略
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	if useTLS {
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	client := server.Client()
+	if tr, ok := client.Transport.(*http.Transport); ok {
+		tr.MaxIdleConnsPerHost = clients
+	}
+	return server, client
+}
+
+// httpClientTest 单个长连接客户端交替执行GET和POST请求，返回每次请求的耗时
+func httpClientTest(client *http.Client, baseURL string, n, seed int) ([]time.Duration, error) {
+	doc := newSerialDocument()
+	samples := make([]time.Duration, n)
+	var body bytes.Buffer
+	for i := range samples {
+		idx := seed + i
+		start := time.Now()
+		var resp *http.Response
+		var result interface{}
+		var err error
+		if i%2 == 0 {
+			result = &serialOrder{}
+			resp, err = client.Get(baseURL + "/orders/" + strconv.Itoa(idx))
+		} else {
+			body.Reset()
+			if err = json.NewEncoder(&body).Encode(&doc.Orders[idx%len(doc.Orders)]); err != nil {
+				return nil, err
+			}
+			result = &httpOrderResponse{}
+			resp, err = client.Post(baseURL+"/orders", "application/json", &body)
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = json.NewDecoder(resp.Body).Decode(result)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("HTTP状态码 %d", resp.StatusCode)
+		}
+		samples[i] = time.Since(start)
+	}
+	return samples, nil
+}
+
+// httpLoadTest 以clients个并发长连接客户端各发送n个请求，返回总耗时和全部请求耗时
+func httpLoadTest(useTLS bool, clients, n int) (time.Duration, []time.Duration, error) {
+	server, client := startHTTPServer(useTLS, clients)
+	defer server.Close()
+	defer client.CloseIdleConnections()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	samples := make([]time.Duration, 0, clients*n)
+	start := time.Now()
+	for c := 0; c < clients; c++ {
+		wg.Add(1)
+		go func(seed int) {
+			defer wg.Done()
+			s, err := httpClientTest(client, server.URL, n, seed)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			samples = append(samples, s...)
+		}(c * n)
+	}
+	wg.Wait()
+	return time.Since(start), samples, firstErr
+}
+
+// tlsHandshakeTest 以clients个并发客户端各新建n条TLS连接，不复用会话，返回总耗时和每次握手的耗时
+func tlsHandshakeTest(clients, n int) (time.Duration, []time.Duration, error) {
+	server, client := startHTTPServer(true, clients)
+	defer server.Close()
+	tr, ok := client.Transport.(*http.Transport)
+	if !ok {
+		return 0, nil, fmt.Errorf("无法获取TLS客户端配置")
+	}
+	config := tr.TLSClientConfig.Clone()
+	config.ClientSessionCache = nil
+	addr := server.Listener.Addr().String()
+
+	var mu sync.Mutex
+	samples := make([]time.Duration, 0, clients*n)
+	elapsed, err := runNetClients(clients, func() error {
+		local := make([]time.Duration, 0, n)
+		defer func() {
+			mu.Lock()
+			samples = append(samples, local...)
+			mu.Unlock()
+		}()
+		for i := 0; i < n; i++ {
+			start := time.Now()
+			conn, err := tls.Dial("tcp", addr, config)
+			if err != nil {
+				return err
+			}
+			local = append(local, time.Since(start))
+			_ = conn.Close()
+		}
+		return nil
+	})
+	return elapsed, samples, err
+}
+
+// HTTPBenchmark 本地回环HTTP服务器测试
+type HTTPBenchmark struct {
+	*BaseBenchmark
+	clients int
+	useTLS  bool
+}
+
+// NewHTTPBenchmark 创建HTTP测试实例，clients为0时使用-proc个客户端，useTLS额外测试HTTPS
+func NewHTTPBenchmark(clients int, useTLS bool) *HTTPBenchmark {
+	b := &HTTPBenchmark{clients: clients, useTLS: useTLS}
+	testFunc := func(workload int) {
+		_, _, err := httpLoadTest(false, 1, workload)
+		b.recordError(err)
+	}
+	b.BaseBenchmark = NewBaseBenchmark(
+		"HTTP服务测试（Loopback HTTP）",
+		"测试进程内net/http服务器处理JSON请求的吞吐量和延迟",
+		"网络性能",
+		testFunc,
+		2000, // 2000次请求
+	)
+	return b
+}
+
+// Run 执行基准测试，并以多个并发长连接客户端测量每秒请求数和延迟分位数
+func (b *HTTPBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		clients := b.clients
+		if clients <= 0 {
+			clients = proc
+		}
+		for _, useTLS := range []bool{false, true} {
+			if useTLS && !b.useTLS {
+				continue
+			}
+			name := "HTTP"
+			if useTLS {
+				name = "HTTPS"
+			}
+			elapsed, samples, err := httpLoadTest(useTLS, clients, b.workload)
+			if err != nil {
+				res.Notes = append(res.Notes, fmt.Sprintf("%s测试失败: %v", name, err))
+				continue
+			}
+			res.Metrics = append(res.Metrics, BenchmarkMetric{Name: name + "吞吐", Value: float64(len(samples)) / elapsed.Seconds(), Unit: "req/s"})
+			res.Metrics = append(res.Metrics, latencyMetrics(name+"请求延迟", samples)...)
+		}
+		res.Notes = append(res.Notes, fmt.Sprintf("使用%d个并发长连接客户端，每个发送%d个请求", clients, b.workload))
+		if b.useTLS {
+			res.Notes = append(res.Notes, "HTTPS延迟包含每个客户端首次请求的TLS握手")
+			// 长连接吞吐只反映TLS记录层加解密，另外单独测量新建连接的握手速率
+			handshakes := b.workload / 10
+			elapsed, samples, err := tlsHandshakeTest(clients, handshakes)
+			if err != nil {
+				res.Notes = append(res.Notes, fmt.Sprintf("TLS握手测试失败: %v", err))
+				return
+			}
+			res.Metrics = append(res.Metrics, BenchmarkMetric{Name: "TLS握手", Value: float64(len(samples)) / elapsed.Seconds(), Unit: "conn/s"})
+			res.Metrics = append(res.Metrics, latencyMetrics("TLS握手延迟", samples)...)
+			res.Notes = append(res.Notes, fmt.Sprintf("TLS握手使用%d个并发客户端，每个新建%d条连接，不复用会话", clients, handshakes))
+		}
+	})
+}
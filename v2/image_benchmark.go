@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	syntheticOnce  sync.Once
+	syntheticImage *image.RGBA
+)
+
+// getSyntheticImage 生成640x480的确定性测试图像：渐变背景、圆形色块和少量噪声
+func getSyntheticImage() *image.RGBA {
+	syntheticOnce.Do(func() {
+		const width, height = 640, 480
+		r := rand.New(rand.NewSource(42))
+		img := image.NewRGBA(image.Rect(0, 0, width, height))
+		type circle struct {
+			x, y, radius int
+			c            color.RGBA
+		}
+		circles := make([]circle, 12)
+		for i := range circles {
+			circles[i] = circle{r.Intn(width), r.Intn(height), 20 + r.Intn(80),
+				color.RGBA{uint8(r.Intn(256)), uint8(r.Intn(256)), uint8(r.Intn(256)), 255}}
+		}
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				c := color.RGBA{uint8(x * 255 / width), uint8(y * 255 / height), 128, 255}
+				for _, ci := range circles {
+					dx, dy := x-ci.x, y-ci.y
+					if dx*dx+dy*dy <= ci.radius*ci.radius {
+						c = ci.c
+					}
+				}
+				noise := uint8(r.Intn(16))
+				c.R, c.G, c.B = c.R|noise, c.G|noise, c.B|noise
+				img.SetRGBA(x, y, c)
+			}
+		}
+		syntheticImage = img
+	})
+	return syntheticImage
+}
+
+// imageCodec 图像编解码器
+type imageCodec struct {
+	name   string
+	encode func(img image.Image) []byte
+	decode func(data []byte)
+}
+
+var (
+	pngCodec = imageCodec{"PNG",
+		func(img image.Image) []byte {
+			var buf bytes.Buffer
+			_ = png.Encode(&buf, img)
+			return buf.Bytes()
+		},
+		func(data []byte) { _, _ = png.Decode(bytes.NewReader(data)) },
+	}
+	jpegCodec = imageCodec{"JPEG",
+		func(img image.Image) []byte {
+			var buf bytes.Buffer
+			_ = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+			return buf.Bytes()
+		},
+		func(data []byte) { _, _ = jpeg.Decode(bytes.NewReader(data)) },
+	}
+	gifCodec = imageCodec{"GIF",
+		func(img image.Image) []byte {
+			var buf bytes.Buffer
+			_ = gif.Encode(&buf, img, nil)
+			return buf.Bytes()
+		},
+		func(data []byte) { _, _ = gif.Decode(bytes.NewReader(data)) },
+	}
+)
+
+// ImageCodecBenchmark 图像编解码测试，每个实例测试一种格式
+type ImageCodecBenchmark struct {
+	*BaseBenchmark
+	codec imageCodec
+}
+
+func newImageCodecBenchmark(codec imageCodec, name, description string, workload int) *ImageCodecBenchmark {
+	testFunc := func(workload int) {
+		img := getSyntheticImage()
+		for i := 0; i < workload; i++ {
+			codec.decode(codec.encode(img))
+		}
+	}
+
+	return &ImageCodecBenchmark{
+		BaseBenchmark: NewBaseBenchmark(name, description, "多媒体性能", testFunc, workload),
+		codec:         codec,
+	}
+}
+
+// NewPNGBenchmark 创建PNG编解码测试实例
+func NewPNGBenchmark() *ImageCodecBenchmark {
+	return newImageCodecBenchmark(pngCodec,
+		"PNG编解码（image/png）",
+		"测试PNG图像编码和解码性能",
+		2, // 2次编解码
+	)
+}
+
+// NewJPEGBenchmark 创建JPEG编解码测试实例
+func NewJPEGBenchmark() *ImageCodecBenchmark {
+	return newImageCodecBenchmark(jpegCodec,
+		"JPEG编解码（image/jpeg）",
+		"测试JPEG图像编码和解码性能",
+		10, // 10次编解码
+	)
+}
+
+// NewGIFBenchmark 创建GIF编解码测试实例
+func NewGIFBenchmark() *ImageCodecBenchmark {
+	return newImageCodecBenchmark(gifCodec,
+		"GIF编解码（image/gif）",
+		"测试GIF图像调色板量化编码和解码性能",
+		1, // 1次编解码
+	)
+}
+
+// Run 执行基准测试，并分别测量编码和解码的每秒百万像素数
+func (b *ImageCodecBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		img := getSyntheticImage()
+		mpix := float64(img.Bounds().Dx()*img.Bounds().Dy()) * float64(b.workload) / 1e6
+
+		var data []byte
+		start := time.Now()
+		for i := 0; i < b.workload; i++ {
+			data = b.codec.encode(img)
+		}
+		encodeTime := time.Since(start)
+		start = time.Now()
+		for i := 0; i < b.workload; i++ {
+			b.codec.decode(data)
+		}
+		decodeTime := time.Since(start)
+		res.Metrics = append(res.Metrics,
+			BenchmarkMetric{Name: "编码", Value: mpix / encodeTime.Seconds(), Unit: "Mpix/s"},
+			BenchmarkMetric{Name: "解码", Value: mpix / decodeTime.Seconds(), Unit: "Mpix/s"},
+		)
+		res.Notes = append(res.Notes, fmt.Sprintf("%dx%d 图像编码后 %s", img.Bounds().Dx(), img.Bounds().Dy(), formatBytes(len(data))))
+	})
+}
+
+// GaussianBlurBenchmark 高斯模糊滤镜测试
+type GaussianBlurBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewGaussianBlurBenchmark 创建高斯模糊测试实例
+func NewGaussianBlurBenchmark() *GaussianBlurBenchmark {
+	testFunc := func(workload int) {
+		img := getSyntheticImage()
+		for i := 0; i < workload; i++ {
+			_ = gaussianBlur(img, 2)
+		}
+	}
+
+	return &GaussianBlurBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"高斯模糊（Gaussian Blur）",
+			"测试基于image.RGBA的可分离高斯卷积滤镜性能",
+			"多媒体性能",
+			testFunc,
+			2, // 2次模糊
+		),
+	}
+}
+
+// Run 执行基准测试，并测量模糊滤镜的每秒百万像素数
+func (b *GaussianBlurBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		img := getSyntheticImage()
+		start := time.Now()
+		for i := 0; i < b.workload; i++ {
+			_ = gaussianBlur(img, 2)
+		}
+		mpix := float64(img.Bounds().Dx()*img.Bounds().Dy()) * float64(b.workload) / 1e6
+		res.Metrics = append(res.Metrics, BenchmarkMetric{Name: "sigma=2", Value: mpix / time.Since(start).Seconds(), Unit: "Mpix/s"})
+	})
+}
+
+// gaussianBlur 先水平后垂直两次一维卷积，边缘像素按最近像素延拓
+func gaussianBlur(src *image.RGBA, sigma float64) *image.RGBA {
+	radius := int(math.Ceil(3 * sigma))
+	kernel := make([]float64, 2*radius+1)
+	sum := 0.0
+	for i := range kernel {
+		d := float64(i - radius)
+		kernel[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += kernel[i]
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	clamp := func(v, hi int) int {
+		if v < 0 {
+			return 0
+		}
+		if v > hi {
+			return hi
+		}
+		return v
+	}
+	pass := func(in, out *image.RGBA, dx, dy int) {
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				var acc [4]float64
+				for k, w := range kernel {
+					sx := clamp(x+(k-radius)*dx, width-1)
+					sy := clamp(y+(k-radius)*dy, height-1)
+					off := sy*in.Stride + sx*4
+					p := in.Pix[off : off+4 : off+4]
+					acc[0] += w * float64(p[0])
+					acc[1] += w * float64(p[1])
+					acc[2] += w * float64(p[2])
+					acc[3] += w * float64(p[3])
+				}
+				off := y*out.Stride + x*4
+				for c := 0; c < 4; c++ {
+					out.Pix[off+c] = uint8(acc[c] + 0.5)
+				}
+			}
+		}
+	}
+	tmp := image.NewRGBA(bounds)
+	dst := image.NewRGBA(bounds)
+	pass(src, tmp, 1, 0)
+	pass(tmp, dst, 0, 1)
+	return dst
+}
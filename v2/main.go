@@ -3,8 +3,11 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+	"os/signal"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/klauspost/cpuid/v2"
@@ -16,23 +19,75 @@ func main() {
 		times    int
 		category string
 		output   string
+		gcHeap   int
+		gcSweep  bool
+		matrix   int
+		render   string
+		ioDir    string
+		skipIO   bool
+		httpConn int
+		httpTLS  bool
+		refOut   string
+		combined bool
+		weights  string
 	)
 	P := runtime.GOMAXPROCS(0)
 	flag.IntVar(&proc, "proc", P, "Processor count")
 	flag.IntVar(&times, "times", 3, "Test times per processor")
 	flag.StringVar(&category, "category", "", "Run specific category only")
 	flag.StringVar(&output, "output", "", "Output report to file")
+	flag.IntVar(&gcHeap, "gc-heap", defaultGCHeapMB, "Live heap size in MB for GC benchmark (split across concurrent tasks in the multi-core run)")
+	flag.BoolVar(&gcSweep, "gc-sweep", false, "Sweep GOGC and GOMEMLIMIT in GC benchmark")
+	flag.IntVar(&matrix, "matrix-max", 1024, "Max matrix size for matrix benchmark (up to 2048)")
+	flag.StringVar(&render, "render-dir", "", "Save rendered images as PNG to directory")
+	flag.StringVar(&ioDir, "io-dir", os.TempDir(), "Directory for file I/O benchmark")
+	flag.BoolVar(&skipIO, "skip-io", false, "Skip file I/O benchmark")
+	flag.IntVar(&httpConn, "http-clients", 0, "Concurrent keep-alive clients for HTTP benchmark (0 = proc)")
+	flag.BoolVar(&httpTLS, "http-tls", false, "Also run HTTP benchmark over TLS")
+	flag.StringVar(&weights, "weights", "default", "Weight profile: built-in name (default, web-server, batch-compute) or JSON file")
+	flag.BoolVar(&combined, "combined", false, "Also show combined score (80% single + 20% multi)")
+	flag.StringVar(&refOut, "write-reference", "", "Save this run as reference machine timings (JSON)")
 	flag.Parse()
+	if gcHeap <= 0 {
+		gcHeap = defaultGCHeapMB
+	}
+	// 被中断或终止时清理存储测试的临时文件
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		cleanupTempDirs()
+		os.Exit(1)
+	}()
 	// 显示CPU信息
 	printCPUInfo()
 	// 创建测试套件
-	suite := NewBenchmarkSuite()
-	calculator := NewScoreCalculator()
-	// 过滤特定类别
+	opts := SuiteOptions{
+		GCLiveHeapMB:  gcHeap,
+		GCSweep:       gcSweep,
+		MatrixMaxSize: matrix,
+		RenderDir:     render,
+		StorageDir:    ioDir,
+		SkipStorage:   skipIO,
+		HTTPClients:   httpConn,
+		HTTPTLS:       httpTLS,
+	}
+	suite := NewBenchmarkSuite(opts)
+	// 加载并校验权重方案
+	profile, err := LoadWeightProfile(weights)
+	if err == nil {
+		err = validateProfile(profile, opts)
+	}
+	if err != nil {
+		fmt.Printf("权重方案无效: %v\n", err)
+		os.Exit(1)
+	}
+	calculator := NewScoreCalculator(profile, combined)
+	// 过滤特定类别，按权重方案中的类别映射匹配
 	if category != "" {
 		filteredSuite := &BenchmarkSuite{}
 		for _, benchmark := range suite.benchmarks {
-			if benchmark.Category() == category {
+			if profile.CategoryOf(benchmark.Name(), benchmark.Category()) == category {
 				filteredSuite.AddBenchmark(benchmark)
 			}
 		}
@@ -57,6 +112,15 @@ func main() {
 			fmt.Printf("报告已保存到: %s\n", output)
 		}
 	}
+	// 保存参考机校准表
+	if refOut != "" {
+		err := writeReference(results, refOut, referenceFlags{Proc: proc, Times: times, GCHeapMB: gcHeap})
+		if err != nil {
+			fmt.Printf("保存参考机校准表失败: %v\n", err)
+		} else {
+			fmt.Printf("参考机校准表已保存到: %s\n", refOut)
+		}
+	}
 }
 
 func printCPUInfo() {
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// mapSizes 哈希表规模测试的元素数量
+var mapSizes = []int{1000, 10000, 100000, 1000000, 10000000}
+
+// mapOpsTarget 规模测试中每项操作的最少执行次数，小规模时重复多轮以保证计时稳定
+const mapOpsTarget = 1 << 18
+
+// mapValue map[[16]byte]T 中使用的值类型
+type mapValue struct {
+	id    int64
+	score float64
+}
+
+// mapTimings 各项操作的平均耗时（ns/op）
+type mapTimings struct {
+	insert, hit, miss, iterate, remove float64
+}
+
+// mapKind 参与测试的map类型，run生成n个键并测试各项操作，每项操作至少执行target次
+type mapKind struct {
+	name string
+	run  func(n, target int, r *rand.Rand) mapTimings
+}
+
+var mapKinds = []mapKind{
+	{"map[int]int", func(n, target int, r *rand.Rand) mapTimings {
+		return mapOpsTest(n, target, func() int { return r.Int() }, 1)
+	}},
+	{"map[string]struct{}", func(n, target int, r *rand.Rand) mapTimings {
+		return mapOpsTest(n, target, func() string { return "key-" + strconv.FormatUint(r.Uint64(), 36) }, struct{}{})
+	}},
+	{"map[[16]byte]T", func(n, target int, r *rand.Rand) mapTimings {
+		return mapOpsTest(n, target, func() (k [16]byte) {
+			_, _ = r.Read(k[:])
+			return
+		}, mapValue{id: 1, score: 0.5})
+	}},
+}
+
+// mapOpsTest 测试插入、命中查找、未命中查找、遍历和删除
+func mapOpsTest[K comparable, V any](n, target int, newKey func() K, value V) (t mapTimings) {
+	keys := make([]K, n)
+	for i := range keys {
+		keys[i] = newKey()
+	}
+	misses := make([]K, n)
+	if len(misses) > 1<<16 {
+		misses = misses[:1<<16]
+	}
+	for i := range misses {
+		misses[i] = newKey()
+	}
+	rounds := target / n
+	if rounds < 1 {
+		rounds = 1
+	}
+	build := func() map[K]V {
+		m := make(map[K]V)
+		for _, k := range keys {
+			m[k] = value
+		}
+		return m
+	}
+	perOp := func(d time.Duration, ops int) float64 {
+		return float64(d.Nanoseconds()) / float64(ops)
+	}
+
+	var m map[K]V
+	start := time.Now()
+	for i := 0; i < rounds; i++ {
+		m = build()
+	}
+	t.insert = perOp(time.Since(start), rounds*n)
+
+	lookups := rounds * n
+	found := 0
+	start = time.Now()
+	for i := 0; i < lookups; i++ {
+		if _, ok := m[keys[i%n]]; ok {
+			found++
+		}
+	}
+	t.hit = perOp(time.Since(start), lookups)
+	start = time.Now()
+	for i := 0; i < lookups; i++ {
+		if _, ok := m[misses[i%len(misses)]]; ok {
+			found++
+		}
+	}
+	t.miss = perOp(time.Since(start), lookups)
+
+	start = time.Now()
+	for i := 0; i < rounds; i++ {
+		for range m {
+			found++
+		}
+	}
+	t.iterate = perOp(time.Since(start), rounds*n)
+
+	var removeTime time.Duration
+	for i := 0; i < rounds; i++ {
+		if i > 0 {
+			m = build()
+		}
+		start = time.Now()
+		for _, k := range keys {
+			delete(m, k)
+		}
+		removeTime += time.Since(start)
+	}
+	t.remove = perOp(removeTime, rounds*n)
+	_ = found
+	return
+}
+
+// syncMapReadTest readers个goroutine并发读取sync.Map，同时有一个写者持续更新，返回每次读取的耗时
+func syncMapReadTest(readers, reads int) float64 {
+	const keys = 100000
+	var m sync.Map
+	for i := 0; i < keys; i++ {
+		m.Store(i, i)
+	}
+	stop := make(chan struct{})
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				m.Store(i%keys, i)
+			}
+		}
+	}()
+	start := time.Now()
+	runContended(readers, reads, func(id, n int) {
+		for i := 0; i < n; i++ {
+			m.Load((id*7919 + i) % keys)
+		}
+	})
+	d := time.Since(start)
+	close(stop)
+	<-writerDone
+	return float64(d.Nanoseconds()) * float64(readers) / float64(reads)
+}
+
+// MapBenchmark 哈希表性能测试
+type MapBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewMapBenchmark 创建哈希表测试实例
+func NewMapBenchmark() *MapBenchmark {
+	testFunc := func(workload int) {
+		r := rand.New(rand.NewSource(42))
+		for _, kind := range mapKinds {
+			_ = kind.run(workload, 0, r)
+		}
+	}
+
+	return &MapBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"哈希表测试（Map Operations）",
+			"测试不同键类型和规模下map的插入、查找、遍历、删除及sync.Map并发读性能",
+			"算法性能",
+			testFunc,
+			100000, // 10万个元素
+		),
+	}
+}
+
+// Run 执行基准测试，并在1K~10M规模下测量各项操作耗时以观察缓存断崖
+func (b *MapBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		r := rand.New(rand.NewSource(42))
+		res.Notes = append(res.Notes, fmt.Sprintf("%s %s %s %s %s %s %s  (ns/op)",
+			padRight("类型", 20), padLeft("规模", 6), padLeft("插入", 8), padLeft("命中", 8), padLeft("未命中", 8), padLeft("遍历", 8), padLeft("删除", 8)))
+		for _, kind := range mapKinds {
+			for _, n := range mapSizes {
+				t := kind.run(n, mapOpsTarget, r)
+				res.Notes = append(res.Notes, fmt.Sprintf("%s %6s %8.2f %8.2f %8.2f %8.2f %8.2f",
+					padRight(kind.name, 20), formatCount(n), t.insert, t.hit, t.miss, t.iterate, t.remove))
+			}
+		}
+		for _, readers := range contentionLevels(proc) {
+			res.Metrics = append(res.Metrics, BenchmarkMetric{
+				Name:  fmt.Sprintf("sync.Map读/%d", readers),
+				Value: syncMapReadTest(readers, mapOpsTarget),
+				Unit:  "ns/op",
+			})
+		}
+	})
+}
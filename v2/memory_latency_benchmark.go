@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klauspost/cpuid/v2"
+)
+
+// maxLatencyWorkingSet 延迟测试的最大工作集，避免在超大L3的机器上耗尽内存
+const maxLatencyWorkingSet = 256 * 1024 * 1024
+
+// latencyChain 随机排列的指针追逐环，每个缓存行存放一个节点
+type latencyChain struct {
+	size int      // 工作集大小（字节）
+	next []uint64 // 节点首个字保存下一跳的下标
+}
+
+// newLatencyChain 使用Sattolo算法生成覆盖全部缓存行的单一随机环
+func newLatencyChain(size, lineSize int, r *rand.Rand) *latencyChain {
+	stride := lineSize / 8
+	lines := size / lineSize
+	perm := make([]int, lines)
+	for i := range perm {
+		perm[i] = i
+	}
+	for i := lines - 1; i > 0; i-- {
+		j := r.Intn(i)
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+	next := make([]uint64, lines*stride)
+	for i, j := range perm {
+		next[i*stride] = uint64(j * stride)
+	}
+	return &latencyChain{size: lines * lineSize, next: next}
+}
+
+// chase 沿环追逐指定次数，返回最终位置防止被优化
+func (c *latencyChain) chase(loads int) uint64 {
+	idx := uint64(0)
+	for i := 0; i < loads; i++ {
+		idx = c.next[idx]
+	}
+	return idx
+}
+
+// MemoryLatencyBenchmark 内存延迟阶梯测试
+type MemoryLatencyBenchmark struct {
+	*BaseBenchmark
+	once   sync.Once
+	chains []*latencyChain
+}
+
+// NewMemoryLatencyBenchmark 创建内存延迟阶梯测试实例
+func NewMemoryLatencyBenchmark() *MemoryLatencyBenchmark {
+	b := &MemoryLatencyBenchmark{}
+	testFunc := func(workload int) {
+		for _, chain := range b.getChains() {
+			_ = chain.chase(workload)
+		}
+	}
+	b.BaseBenchmark = NewBaseBenchmark(
+		"内存延迟阶梯（Memory Latency Ladder）",
+		"通过随机指针追逐测试各级缓存和内存的访问延迟",
+		"内存性能",
+		testFunc,
+		1<<18, // 每个工作集追逐约26万次
+	)
+	return b
+}
+
+// Run 执行基准测试，并逐个工作集测量单次访问延迟
+func (b *MemoryLatencyBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		for _, chain := range b.getChains() {
+			// 预热缓存和TLB，超大工作集只需部分预热
+			warmup := len(chain.next) / (cacheLineSize() / 8)
+			if warmup > b.workload {
+				warmup = b.workload
+			}
+			_ = chain.chase(warmup)
+			start := time.Now()
+			_ = chain.chase(b.workload)
+			ns := float64(time.Since(start).Nanoseconds()) / float64(b.workload)
+			res.Metrics = append(res.Metrics, BenchmarkMetric{Name: formatBytes(chain.size), Value: ns, Unit: "ns/load"})
+		}
+		res.Notes = append(res.Notes, "延迟曲线（对数刻度）:")
+		res.Notes = append(res.Notes, renderBarChart(res.Metrics, 40)...)
+		// 释放追逐环，避免占用的大块内存影响后续测试的GC节奏
+		b.chains = nil
+		b.once = sync.Once{}
+	})
+}
+
+// getChains 按需构建各工作集的追逐环，所有goroutine共享只读数据
+func (b *MemoryLatencyBenchmark) getChains() []*latencyChain {
+	b.once.Do(func() {
+		r := rand.New(rand.NewSource(42))
+		for _, size := range latencyWorkingSets() {
+			b.chains = append(b.chains, newLatencyChain(size, cacheLineSize(), r))
+		}
+	})
+	return b.chains
+}
+
+// latencyWorkingSets 根据各级缓存大小挑选有代表性的工作集
+func latencyWorkingSets() []int {
+	l1, l2, l3 := cpuid.CPU.Cache.L1D, cpuid.CPU.Cache.L2, cpuid.CPU.Cache.L3
+	if l1 <= 0 {
+		l1 = 32 * 1024
+	}
+	if l2 <= 0 {
+		l2 = 256 * 1024
+	}
+	if l3 <= 0 {
+		l3 = 8 * 1024 * 1024
+	}
+	candidates := []int{4 * 1024, l1 / 2, l1, l1 * 2, l2 / 2, l2, l2 * 2, l3 / 2, l3, l3 * 2, l3 * 4}
+	lineSize := cacheLineSize()
+	seen := make(map[int]bool)
+	var sizes []int
+	for _, size := range candidates {
+		if size > maxLatencyWorkingSet {
+			size = maxLatencyWorkingSet
+		}
+		size = size / lineSize * lineSize
+		if size < 4*1024 || seen[size] {
+			continue
+		}
+		seen[size] = true
+		sizes = append(sizes, size)
+	}
+	sort.Ints(sizes)
+	return sizes
+}
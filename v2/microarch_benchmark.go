@@ -0,0 +1,182 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+const microarchDataSize = 1 << 16 // 64K个元素，数据常驻缓存，避免内存带宽干扰
+
+var (
+	microarchOnce   sync.Once
+	microarchRandom []uint8 // 随机字节，分支方向不可预测
+	microarchSorted []uint8 // 相同字节排序后，分支方向可预测
+	microarchWords  []uint32
+)
+
+// getMicroarchData 延迟生成共享的只读测试数据
+func getMicroarchData() ([]uint8, []uint8, []uint32) {
+	microarchOnce.Do(func() {
+		r := rand.New(rand.NewSource(42))
+		microarchRandom = make([]uint8, microarchDataSize)
+		_, _ = r.Read(microarchRandom)
+		microarchSorted = append([]uint8(nil), microarchRandom...)
+		sort.Slice(microarchSorted, func(i, j int) bool { return microarchSorted[i] < microarchSorted[j] })
+		microarchWords = make([]uint32, microarchDataSize)
+		for i := range microarchWords {
+			microarchWords[i] = r.Uint32()
+		}
+	})
+	return microarchRandom, microarchSorted, microarchWords
+}
+
+// microarchContrasts 微架构对比测试，每项用同样的操作数分别运行基准和对照两种写法
+// mispredictRate大于0时，按两者耗时差除以误预测率估算单次分支误预测的惩罚
+var microarchContrasts = []struct {
+	name           string
+	base, variant  string
+	runBase        func(n int) uint64
+	runVariant     func(n int) uint64
+	mispredictRate float64
+}{
+	{"分支预测", "有序数据", "随机数据", sortedBranchTest, randomBranchTest, 0.5},
+	{"指令级并行", "4条独立链", "单条依赖链", independentChainTest, dependentChainTest, 0},
+	{"整数除法", "乘法", "除法", multiplyThroughputTest, divideThroughputTest, 0},
+	{"循环展开", "4路展开", "未展开", unrolledLoopTest, rolledLoopTest, 0},
+}
+
+// MicroarchBenchmark 分支预测和指令级并行微基准测试
+type MicroarchBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewMicroarchBenchmark 创建微架构测试实例
+func NewMicroarchBenchmark() *MicroarchBenchmark {
+	testFunc := func(workload int) {
+		for _, c := range microarchContrasts {
+			_ = c.runBase(workload)
+			_ = c.runVariant(workload)
+		}
+	}
+
+	return &MicroarchBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"微架构测试（Microarchitecture）",
+			"对比可预测与随机分支、依赖与独立运算链、乘除法和循环展开",
+			"微架构",
+			testFunc,
+			1<<23, // 每项约800万次操作
+		),
+	}
+}
+
+// Run 执行基准测试，并报告每组对比的单次操作耗时、倍数和误预测惩罚
+func (b *MicroarchBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		n := float64(b.workload)
+		for _, c := range microarchContrasts {
+			runBase, runVariant := c.runBase, c.runVariant
+			base := float64(measureSingle(func() { _ = runBase(b.workload) }).Nanoseconds()) / n
+			variant := float64(measureSingle(func() { _ = runVariant(b.workload) }).Nanoseconds()) / n
+			res.Metrics = append(res.Metrics,
+				BenchmarkMetric{Name: c.name + "/" + c.base, Value: base, Unit: "ns/op"},
+				BenchmarkMetric{Name: c.name + "/" + c.variant, Value: variant, Unit: "ns/op"},
+				BenchmarkMetric{Name: c.name + "/倍数", Value: variant / base, Unit: "x"},
+			)
+			if c.mispredictRate > 0 {
+				res.Metrics = append(res.Metrics, BenchmarkMetric{Name: c.name + "/误预测惩罚", Value: (variant - base) / c.mispredictRate, Unit: "ns"})
+			}
+		}
+	})
+}
+
+// branchTest 遍历数据n次，两个分支执行不同的运算，防止编译器改写为条件传送
+func branchTest(data []uint8, n int) uint64 {
+	var sum, other uint64
+	for i := 0; i < n; i++ {
+		v := uint64(data[i&(microarchDataSize-1)])
+		if v >= 128 {
+			sum += v * 3
+		} else {
+			other ^= v << 2
+		}
+	}
+	return sum + other
+}
+
+func sortedBranchTest(n int) uint64 {
+	_, sorted, _ := getMicroarchData()
+	return branchTest(sorted, n)
+}
+
+func randomBranchTest(n int) uint64 {
+	random, _, _ := getMicroarchData()
+	return branchTest(random, n)
+}
+
+// dependentChainTest 每次乘加都依赖上一次的结果，耗时受指令延迟限制
+func dependentChainTest(n int) uint64 {
+	x := uint64(1)
+	for i := 0; i < n; i++ {
+		x = x*6364136223846793005 + 1442695040888963407
+	}
+	return x
+}
+
+// independentChainTest 同样次数的乘加分布在4条互不依赖的链上，可被乱序执行并行处理
+func independentChainTest(n int) uint64 {
+	a, b, c, d := uint64(1), uint64(2), uint64(3), uint64(4)
+	for i := 0; i < n; i += 4 {
+		a = a*6364136223846793005 + 1442695040888963407
+		b = b*6364136223846793005 + 1442695040888963407
+		c = c*6364136223846793005 + 1442695040888963407
+		d = d*6364136223846793005 + 1442695040888963407
+	}
+	return a ^ b ^ c ^ d
+}
+
+// multiplyThroughputTest 用运行期才确定的操作数执行n次互不依赖的乘法
+func multiplyThroughputTest(n int) uint64 {
+	_, _, words := getMicroarchData()
+	var sum uint64
+	for i := 0; i < n; i++ {
+		sum += (uint64(i) + 1<<40) * uint64(words[i&(microarchDataSize-1)]|1)
+	}
+	return sum
+}
+
+// divideThroughputTest 用运行期才确定的除数执行n次互不依赖的64位除法
+func divideThroughputTest(n int) uint64 {
+	_, _, words := getMicroarchData()
+	var sum uint64
+	for i := 0; i < n; i++ {
+		sum += (uint64(i) + 1<<40) / uint64(words[i&(microarchDataSize-1)]|1)
+	}
+	return sum
+}
+
+// rolledLoopTest 逐个元素累加
+func rolledLoopTest(n int) uint64 {
+	_, _, words := getMicroarchData()
+	var sum uint64
+	for done := 0; done < n; done += len(words) {
+		for _, w := range words {
+			sum += uint64(w)
+		}
+	}
+	return sum
+}
+
+// unrolledLoopTest 每次迭代累加4个元素，减少循环控制和边界检查开销
+func unrolledLoopTest(n int) uint64 {
+	_, _, words := getMicroarchData()
+	var sum uint64
+	for done := 0; done < n; done += len(words) {
+		for i := 0; i+4 <= len(words); i += 4 {
+			w := words[i : i+4 : i+4]
+			sum += uint64(w[0]) + uint64(w[1]) + uint64(w[2]) + uint64(w[3])
+		}
+	}
+	return sum
+}
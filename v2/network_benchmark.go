@@ -0,0 +1,317 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// loopbackNetworks 参与测试的本地网络类型
+var loopbackNetworks = []struct {
+	name    string
+	network string
+}{
+	{"TCP", "tcp"},
+	{"Unix", "unix"},
+}
+
+// 客户端连接后发送的首字节，决定服务端的处理方式
+const (
+	netModeEcho  = 'E' // 回显固定大小的消息
+	netModeSink  = 'S' // 读取并丢弃数据直到EOF，然后回复1字节确认
+	netModeClose = 'C' // 立即关闭连接
+)
+
+const (
+	netMessageSize = 64        // 往返测试的消息大小
+	netBulkBuffer  = 64 * 1024 // 吞吐测试的写缓冲大小
+)
+
+// loopbackServer 进程内的本地回环服务器
+type loopbackServer struct {
+	ln  net.Listener
+	dir string
+	wg  sync.WaitGroup
+}
+
+// startLoopbackServer 在127.0.0.1或临时目录的Unix套接字上启动服务器
+func startLoopbackServer(network string) (*loopbackServer, error) {
+	s := &loopbackServer{}
+	addr := "127.0.0.1:0"
+	if network == "unix" {
+		dir, err := createTempDir(os.TempDir(), "gohyperpi-sock-*")
+		if err != nil {
+			return nil, err
+		}
+		s.dir = dir
+		addr = filepath.Join(dir, "bench.sock")
+	}
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		if s.dir != "" {
+			removeTempDir(s.dir)
+		}
+		return nil, err
+	}
+	s.ln = ln
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+func (s *loopbackServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *loopbackServer) handle(conn net.Conn) {
+	defer conn.Close()
+	var mode [1]byte
+	if _, err := io.ReadFull(conn, mode[:]); err != nil {
+		return
+	}
+	switch mode[0] {
+	case netModeEcho:
+		buf := make([]byte, netMessageSize)
+		for {
+			if _, err := io.ReadFull(conn, buf); err != nil {
+				return
+			}
+			if _, err := conn.Write(buf); err != nil {
+				return
+			}
+		}
+	case netModeSink:
+		if _, err := io.Copy(io.Discard, conn); err != nil {
+			return
+		}
+		_, _ = conn.Write(mode[:])
+	}
+}
+
+// Addr 返回客户端拨号使用的地址
+func (s *loopbackServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Close 关闭监听并等待所有连接处理结束
+func (s *loopbackServer) Close() {
+	_ = s.ln.Close()
+	s.wg.Wait()
+	if s.dir != "" {
+		removeTempDir(s.dir)
+	}
+}
+
+// netBulkTest 向服务器发送size字节并等待确认，返回耗时
+func netBulkTest(network, addr string, size int) (time.Duration, error) {
+	start := time.Now()
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	buf := make([]byte, netBulkBuffer)
+	buf[0] = netModeSink
+	if _, err = conn.Write(buf[:1]); err != nil {
+		return 0, err
+	}
+	for sent := 0; sent < size; sent += len(buf) {
+		if _, err = conn.Write(buf); err != nil {
+			return 0, err
+		}
+	}
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		if err = cw.CloseWrite(); err != nil {
+			return 0, err
+		}
+	}
+	if _, err = io.ReadFull(conn, buf[:1]); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// netRoundTripTest 在一条连接上执行n次小消息请求应答，返回每次往返的耗时
+func netRoundTripTest(network, addr string, n int) ([]time.Duration, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if _, err = conn.Write([]byte{netModeEcho}); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, netMessageSize)
+	samples := make([]time.Duration, n)
+	for i := range samples {
+		start := time.Now()
+		if _, err = conn.Write(buf); err != nil {
+			return nil, err
+		}
+		if _, err = io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+		samples[i] = time.Since(start)
+	}
+	return samples, nil
+}
+
+// netConnectTest 建立并关闭n次连接，由服务端先关闭以避免客户端端口耗尽
+func netConnectTest(network, addr string, n int) (time.Duration, error) {
+	start := time.Now()
+	var one [1]byte
+	for i := 0; i < n; i++ {
+		conn, err := net.Dial(network, addr)
+		if err != nil {
+			return 0, err
+		}
+		one[0] = netModeClose
+		if _, err = conn.Write(one[:]); err != nil {
+			_ = conn.Close()
+			return 0, err
+		}
+		_, _ = conn.Read(one[:])
+		_ = conn.Close()
+	}
+	return time.Since(start), nil
+}
+
+// networkTest 依次在TCP和Unix套接字上执行吞吐、往返和建连测试，返回第一个错误
+func networkTest(workload int) error {
+	for _, nw := range loopbackNetworks {
+		if err := networkRoundTest(nw.network, workload); err != nil {
+			return fmt.Errorf("%s: %v", nw.name, err)
+		}
+	}
+	return nil
+}
+
+// networkRoundTest 在一种套接字上执行一轮吞吐、往返和建连测试
+func networkRoundTest(network string, workload int) error {
+	server, err := startLoopbackServer(network)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+	if _, err = netBulkTest(network, server.Addr(), workload*1024*1024); err != nil {
+		return err
+	}
+	if _, err = netRoundTripTest(network, server.Addr(), workload*256); err != nil {
+		return err
+	}
+	_, err = netConnectTest(network, server.Addr(), workload*8)
+	return err
+}
+
+// runNetClients 同时启动clients个客户端，返回总耗时和第一个错误
+func runNetClients(clients int, fn func() error) (time.Duration, error) {
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	start := time.Now()
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				once.Do(func() { firstErr = err })
+			}
+		}()
+	}
+	wg.Wait()
+	return time.Since(start), firstErr
+}
+
+// NetworkBenchmark 本地回环网络测试
+type NetworkBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewNetworkBenchmark 创建网络测试实例
+func NewNetworkBenchmark() *NetworkBenchmark {
+	b := &NetworkBenchmark{}
+	testFunc := func(workload int) {
+		b.recordError(networkTest(workload))
+	}
+	b.BaseBenchmark = NewBaseBenchmark(
+		"回环网络测试（Loopback Network）",
+		"测试进程内TCP和Unix套接字的吞吐量、往返延迟和建连速率",
+		"网络性能",
+		testFunc,
+		16, // 16MB数据、4096次往返、128次建连
+	)
+	return b
+}
+
+// Run 执行基准测试，并以proc个并发客户端测量吞吐、延迟分位数和建连速率
+func (b *NetworkBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		size := b.workload * 1024 * 1024
+		rounds := b.workload * 256
+		connects := b.workload * 8
+		for _, nw := range loopbackNetworks {
+			if err := b.measureNetwork(res, nw.name, nw.network, proc, size, rounds, connects); err != nil {
+				res.Notes = append(res.Notes, fmt.Sprintf("%s测试失败: %v", nw.name, err))
+			}
+		}
+		res.Notes = append(res.Notes, fmt.Sprintf("吞吐、延迟和建连速率均使用%d个并发客户端", proc))
+	})
+}
+
+func (b *NetworkBenchmark) measureNetwork(res *BenchmarkResult, name, network string, clients, size, rounds, connects int) error {
+	server, err := startLoopbackServer(network)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+	addr := server.Addr()
+
+	bulk, err := runNetClients(clients, func() error {
+		_, err := netBulkTest(network, addr, size)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var samples []time.Duration
+	if _, err = runNetClients(clients, func() error {
+		s, err := netRoundTripTest(network, addr, rounds)
+		mu.Lock()
+		samples = append(samples, s...)
+		mu.Unlock()
+		return err
+	}); err != nil {
+		return err
+	}
+
+	connect, err := runNetClients(clients, func() error {
+		_, err := netConnectTest(network, addr, connects)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	mb := float64(clients*size) / 1024 / 1024
+	res.Metrics = append(res.Metrics, BenchmarkMetric{Name: name + "吞吐", Value: mb / bulk.Seconds(), Unit: "MB/s"})
+	res.Metrics = append(res.Metrics, latencyMetrics(name+"往返延迟", samples)...)
+	res.Metrics = append(res.Metrics, BenchmarkMetric{Name: name + "建连", Value: float64(clients*connects) / connect.Seconds(), Unit: "conn/s"})
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// pubKeyOp 公钥算法测试项，count为workload为1时的执行次数
+type pubKeyOp struct {
+	name  string
+	count int
+	run   func(k *pubKeys)
+}
+
+// pubKeys 测试使用的密钥，生成后只读共享
+type pubKeys struct {
+	digest   []byte
+	rsa2048  *rsa.PrivateKey
+	rsa4096  *rsa.PrivateKey
+	rsa2048S []byte
+	rsa4096S []byte
+	p256     *ecdsa.PrivateKey
+	p384     *ecdsa.PrivateKey
+	p256S    []byte
+	p384S    []byte
+	ed25519  ed25519.PrivateKey
+	ed25519S []byte
+	x25519   *ecdh.PrivateKey
+	ecdhP256 *ecdh.PrivateKey
+}
+
+// pubKeyOps 按RSA、ECDSA、Ed25519、密钥协商分组的操作，次数按各算法开销大致均衡
+var pubKeyOps = []pubKeyOp{
+	{"RSA-2048签名", 20, func(k *pubKeys) { _, _ = rsa.SignPKCS1v15(rand.Reader, k.rsa2048, crypto.SHA256, k.digest) }},
+	{"RSA-2048验签", 500, func(k *pubKeys) { _ = rsa.VerifyPKCS1v15(&k.rsa2048.PublicKey, crypto.SHA256, k.digest, k.rsa2048S) }},
+	{"RSA-4096签名", 4, func(k *pubKeys) { _, _ = rsa.SignPKCS1v15(rand.Reader, k.rsa4096, crypto.SHA256, k.digest) }},
+	{"RSA-4096验签", 200, func(k *pubKeys) { _ = rsa.VerifyPKCS1v15(&k.rsa4096.PublicKey, crypto.SHA256, k.digest, k.rsa4096S) }},
+	{"ECDSA-P256签名", 500, func(k *pubKeys) { _, _ = ecdsa.SignASN1(rand.Reader, k.p256, k.digest) }},
+	{"ECDSA-P256验签", 200, func(k *pubKeys) { _ = ecdsa.VerifyASN1(&k.p256.PublicKey, k.digest, k.p256S) }},
+	{"ECDSA-P384签名", 100, func(k *pubKeys) { _, _ = ecdsa.SignASN1(rand.Reader, k.p384, k.digest) }},
+	{"ECDSA-P384验签", 50, func(k *pubKeys) { _ = ecdsa.VerifyASN1(&k.p384.PublicKey, k.digest, k.p384S) }},
+	{"Ed25519签名", 500, func(k *pubKeys) { _ = ed25519.Sign(k.ed25519, k.digest) }},
+	{"Ed25519验签", 200, func(k *pubKeys) { _ = ed25519.Verify(k.ed25519.Public().(ed25519.PublicKey), k.digest, k.ed25519S) }},
+	{"X25519密钥协商", 300, func(k *pubKeys) { ecdhExchange(ecdh.X25519(), k.x25519) }},
+	{"ECDH-P256密钥协商", 300, func(k *pubKeys) { ecdhExchange(ecdh.P256(), k.ecdhP256) }},
+}
+
+// ecdhExchange 模拟TLS握手：生成临时密钥并与对端公钥协商共享密钥
+func ecdhExchange(curve ecdh.Curve, peer *ecdh.PrivateKey) {
+	priv, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return
+	}
+	_, _ = priv.ECDH(peer.PublicKey())
+}
+
+// PublicKeyBenchmark 公钥加密算法测试
+type PublicKeyBenchmark struct {
+	*BaseBenchmark
+	once sync.Once
+	keys *pubKeys
+}
+
+// NewPublicKeyBenchmark 创建公钥加密测试实例
+func NewPublicKeyBenchmark() *PublicKeyBenchmark {
+	b := &PublicKeyBenchmark{}
+	testFunc := func(workload int) {
+		keys := b.getKeys()
+		for _, op := range pubKeyOps {
+			for i := 0; i < op.count*workload; i++ {
+				op.run(keys)
+			}
+		}
+	}
+	b.BaseBenchmark = NewBaseBenchmark(
+		"公钥加密测试（Public-Key Cryptography）",
+		"测试RSA、ECDSA、Ed25519签名验签和ECDH密钥协商性能",
+		"加密性能",
+		testFunc,
+		1, // 各操作次数的倍数
+	)
+	return b
+}
+
+// Run 执行基准测试，并测量每种操作的单核每秒次数
+func (b *PublicKeyBenchmark) Run(proc, times int) BenchmarkResult {
+	// 在计时前生成密钥，避免RSA-4096的生成耗时计入第一次单核测量
+	b.getKeys()
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		keys := b.getKeys()
+		for _, op := range pubKeyOps {
+			n := op.count * b.workload
+			start := time.Now()
+			for i := 0; i < n; i++ {
+				op.run(keys)
+			}
+			res.Metrics = append(res.Metrics, BenchmarkMetric{Name: op.name, Value: float64(n) / time.Since(start).Seconds(), Unit: "ops/s"})
+		}
+	})
+}
+
+// getKeys 首次调用时生成密钥和签名，RSA-4096生成较慢，只做一次，由Run在计时前调用
+func (b *PublicKeyBenchmark) getKeys() *pubKeys {
+	b.once.Do(func() {
+		digest := sha256.Sum256([]byte("GoHyperPi public-key benchmark"))
+		k := &pubKeys{digest: digest[:]}
+		k.rsa2048, _ = rsa.GenerateKey(rand.Reader, 2048)
+		k.rsa4096, _ = rsa.GenerateKey(rand.Reader, 4096)
+		k.rsa2048S, _ = rsa.SignPKCS1v15(rand.Reader, k.rsa2048, crypto.SHA256, k.digest)
+		k.rsa4096S, _ = rsa.SignPKCS1v15(rand.Reader, k.rsa4096, crypto.SHA256, k.digest)
+		k.p256, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		k.p384, _ = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		k.p256S, _ = ecdsa.SignASN1(rand.Reader, k.p256, k.digest)
+		k.p384S, _ = ecdsa.SignASN1(rand.Reader, k.p384, k.digest)
+		_, k.ed25519, _ = ed25519.GenerateKey(rand.Reader)
+		k.ed25519S = ed25519.Sign(k.ed25519, k.digest)
+		k.x25519, _ = ecdh.X25519().GenerateKey(rand.Reader)
+		k.ecdhP256, _ = ecdh.P256().GenerateKey(rand.Reader)
+		b.keys = k
+	})
+	return b.keys
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/klauspost/cpuid/v2"
+)
+
+// referenceScore 与参考机性能相同时的得分
+const referenceScore = 1000.0
+
+// referenceJSON 参考机上各测试的耗时，由 -write-reference 参数在参考机上以 -proc 1 -times 1 生成
+//
+//go:embed data/reference.json
+var referenceJSON []byte
+
+// referenceTiming 参考机上单个测试的耗时
+type referenceTiming struct {
+	SingleMs float64 `json:"single_ms"` // 单核耗时
+	MultiMs  float64 `json:"multi_ms"`  // 多核测试中平均每个核心的耗时
+}
+
+// referenceFlags 生成校准表时使用的命令行参数，fillResult据此检查工作量随参数变化的测试：
+// -gc-heap决定GC压力测试的工作量，-proc和-times决定分摊资源预算的测试中多核任务的工作量
+type referenceFlags struct {
+	Proc     int `json:"proc"`
+	Times    int `json:"times"`
+	GCHeapMB int `json:"gc_heap_mb"`
+}
+
+// String 返回与命令行写法一致的参数
+func (f referenceFlags) String() string {
+	return fmt.Sprintf("-proc %d -times %d -gc-heap %d", f.Proc, f.Times, f.GCHeapMB)
+}
+
+// tasks 返回校准时多核测试的并发任务数
+func (f referenceFlags) tasks() int {
+	return f.Proc * f.Times
+}
+
+// referenceTable 参考机校准表
+type referenceTable struct {
+	Machine    string                     `json:"machine"`
+	Flags      referenceFlags             `json:"flags"`
+	Benchmarks map[string]referenceTiming `json:"benchmarks"`
+}
+
+var (
+	referenceOnce sync.Once
+	reference     referenceTable
+)
+
+// getReference 解析内嵌的参考机校准表
+func getReference() *referenceTable {
+	referenceOnce.Do(func() {
+		if err := json.Unmarshal(referenceJSON, &reference); err != nil {
+			reference = referenceTable{Machine: fmt.Sprintf("无效的校准表: %v", err)}
+		}
+	})
+	return &reference
+}
+
+// normalizedScore 按参考耗时/实测耗时×1000计算得分，2000表示比参考机快一倍
+func normalizedScore(referenceMs float64, measured time.Duration) float64 {
+	ms := float64(measured.Nanoseconds()) / 1000000.0
+	if referenceMs <= 0 || ms <= 0 {
+		return 0
+	}
+	return referenceMs / ms * referenceScore
+}
+
+// skipScoring 清除测试得分并说明原因，用于工作量与参考机校准时不同的测试
+func skipScoring(res *BenchmarkResult, reason string) {
+	res.SingleScore, res.MultiScore, res.Score = 0, 0, 0
+	res.Notes = append(res.Notes, reason+"，不计分")
+}
+
+// skipMultiScore 清除多核得分和综合得分并说明原因，单核得分仍然有效
+func skipMultiScore(res *BenchmarkResult, reason string) {
+	res.MultiScore, res.Score = 0, 0
+	res.Notes = append(res.Notes, reason+"，多核不计分")
+}
+
+// perProcDuration 多核耗时折算为平均每个核心的耗时，使不同核心数的机器可与参考机比较
+func perProcDuration(multi time.Duration, proc int) time.Duration {
+	if proc < 1 {
+		proc = 1
+	}
+	return multi / time.Duration(proc)
+}
+
+// geometricMean 计算正数得分的加权几何平均，weights为nil时等权
+func geometricMean(scores, weights []float64) float64 {
+	sum, total := 0.0, 0.0
+	for i, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		sum += w * math.Log(score)
+		total += w
+	}
+	if total == 0 {
+		return 0
+	}
+	return math.Exp(sum / total)
+}
+
+// writeReference 将本次测试结果和生成参数保存为参考机校准表
+func writeReference(results []BenchmarkResult, filename string, flags referenceFlags) error {
+	table := referenceTable{
+		Machine:    fmt.Sprintf("%s (%s/%s)", cpuid.CPU.BrandName, runtime.GOOS, runtime.GOARCH),
+		Flags:      flags,
+		Benchmarks: make(map[string]referenceTiming),
+	}
+	for _, result := range results {
+		table.Benchmarks[result.Name] = referenceTiming{
+			SingleMs: float64(result.SingleDuration.Microseconds()) / 1000,
+			MultiMs:  float64(perProcDuration(result.MultiDuration, result.Proc).Microseconds()) / 1000,
+		}
+	}
+	data, err := json.MarshalIndent(&table, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// renderTileSize 并行渲染时每个分块的边长
+const renderTileSize = 32
+
+// renderScene 渲染场景，shade计算单个像素的颜色，hash为参考像素哈希
+type renderScene struct {
+	name   string
+	file   string
+	width  int
+	height int
+	shade  func(x, y, width, height int) color.RGBA
+	hash   uint64
+}
+
+var renderScenes = []renderScene{
+	{"Mandelbrot", "mandelbrot.png", 512, 512, mandelbrotPixel, 0xc613504071f47e57},
+	{"光线追踪", "raytrace.png", 320, 240, rayTracePixel, 0xc13a13da84571d76},
+}
+
+// renderImage 将图像切分为分块，由workers个goroutine从队列中领取并渲染
+func renderImage(scene renderScene, workers int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, scene.width, scene.height))
+	tiles := make(chan image.Rectangle, (scene.width/renderTileSize+1)*(scene.height/renderTileSize+1))
+	for y := 0; y < scene.height; y += renderTileSize {
+		for x := 0; x < scene.width; x += renderTileSize {
+			tiles <- image.Rect(x, y, x+renderTileSize, y+renderTileSize).Intersect(img.Bounds())
+		}
+	}
+	close(tiles)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for tile := range tiles {
+				for y := tile.Min.Y; y < tile.Max.Y; y++ {
+					for x := tile.Min.X; x < tile.Max.X; x++ {
+						img.SetRGBA(x, y, scene.shade(x, y, scene.width, scene.height))
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return img
+}
+
+// pixelHash 计算像素数据的FNV-1a哈希
+func pixelHash(img *image.RGBA) uint64 {
+	h := fnv.New64a()
+	h.Write(img.Pix)
+	return h.Sum64()
+}
+
+// mandelbrotPixel 计算Mandelbrot集合中一个像素的颜色，最多迭代256次
+func mandelbrotPixel(x, y, width, height int) color.RGBA {
+	const maxIter = 256
+	cr := -2.2 + 3.0*float64(x)/float64(width)
+	ci := -1.5 + 3.0*float64(y)/float64(height)
+	zr, zi := 0.0, 0.0
+	i := 0
+	// 显式转换阻止编译器融合乘加，保证各平台结果一致
+	for ; i < maxIter && float64(zr*zr)+float64(zi*zi) <= 4; i++ {
+		zr, zi = float64(zr*zr)-float64(zi*zi)+cr, float64(2*zr*zi)+ci
+	}
+	if i == maxIter {
+		return color.RGBA{A: 255}
+	}
+	return color.RGBA{R: uint8(i * 9), G: uint8(i * 5), B: uint8(255 - i*3), A: 255}
+}
+
+// vec3 三维向量，乘积均显式转换为float64，阻止编译器在arm64等平台上融合乘加导致像素哈希不一致
+type vec3 struct{ x, y, z float64 }
+
+func (a vec3) add(b vec3) vec3 { return vec3{a.x + b.x, a.y + b.y, a.z + b.z} }
+func (a vec3) sub(b vec3) vec3 { return vec3{a.x - b.x, a.y - b.y, a.z - b.z} }
+func (a vec3) scale(s float64) vec3 {
+	return vec3{float64(a.x * s), float64(a.y * s), float64(a.z * s)}
+}
+func (a vec3) dot(b vec3) float64 { return float64(a.x*b.x) + float64(a.y*b.y) + float64(a.z*b.z) }
+func (a vec3) norm() vec3         { return a.scale(1 / math.Sqrt(a.dot(a))) }
+
+// sphere 场景中的球体
+type sphere struct {
+	center  vec3
+	radius  float64
+	color   vec3
+	reflect float64
+}
+
+var (
+	rtSpheres = []sphere{
+		{vec3{0, 1, 5}, 1, vec3{0.9, 0.2, 0.2}, 0.3},
+		{vec3{-2.2, 0.8, 6}, 0.8, vec3{0.2, 0.9, 0.3}, 0.5},
+		{vec3{2, 0.6, 4.5}, 0.6, vec3{0.2, 0.4, 0.9}, 0.7},
+	}
+	rtLight  = vec3{-4, 6, 0}
+	rtCamera = vec3{0, 1.2, -1}
+)
+
+// rayTracePixel 追踪经过像素的光线，场景包含三个球体和棋盘格地面，支持阴影和两次反射
+func rayTracePixel(x, y, width, height int) color.RGBA {
+	aspect := float64(width) / float64(height)
+	dir := vec3{
+		(2*(float64(x)+0.5)/float64(width) - 1) * aspect,
+		1 - 2*(float64(y)+0.5)/float64(height),
+		1.5,
+	}.norm()
+	c := traceRay(rtCamera, dir, 2)
+	toByte := func(v float64) uint8 {
+		return uint8(math.Min(1, math.Max(0, v)) * 255)
+	}
+	return color.RGBA{toByte(c.x), toByte(c.y), toByte(c.z), 255}
+}
+
+// intersectScene 返回光线最近的交点距离、法线和材质，未命中时t为+Inf
+func intersectScene(origin, dir vec3) (t float64, normal, col vec3, reflect float64) {
+	t = math.Inf(1)
+	for _, s := range rtSpheres {
+		oc := origin.sub(s.center)
+		b := oc.dot(dir)
+		disc := float64(b*b) - (oc.dot(oc) - float64(s.radius*s.radius))
+		if disc < 0 {
+			continue
+		}
+		if d := -b - math.Sqrt(disc); d > 1e-6 && d < t {
+			t = d
+			normal = origin.add(dir.scale(d)).sub(s.center).norm()
+			col, reflect = s.color, s.reflect
+		}
+	}
+	// y=0平面上的棋盘格地面
+	if dir.y < 0 {
+		if d := -origin.y / dir.y; d > 1e-6 && d < t {
+			t = d
+			p := origin.add(dir.scale(d))
+			normal = vec3{0, 1, 0}
+			reflect = 0.2
+			if (int(math.Floor(p.x))+int(math.Floor(p.z)))&1 == 0 {
+				col = vec3{0.9, 0.9, 0.9}
+			} else {
+				col = vec3{0.1, 0.1, 0.1}
+			}
+		}
+	}
+	return
+}
+
+func traceRay(origin, dir vec3, depth int) vec3 {
+	t, normal, col, reflect := intersectScene(origin, dir)
+	if math.IsInf(t, 1) {
+		// 天空渐变
+		return vec3{0.5, 0.7, 1}.scale(0.5 + float64(0.5*dir.y))
+	}
+	hit := origin.add(dir.scale(t))
+	toLight := rtLight.sub(hit)
+	lightDist := math.Sqrt(toLight.dot(toLight))
+	toLight = toLight.scale(1 / lightDist)
+	diffuse := math.Max(0, normal.dot(toLight))
+	if st, _, _, _ := intersectScene(hit.add(normal.scale(1e-4)), toLight); st < lightDist {
+		diffuse *= 0.2
+	}
+	result := col.scale(0.1 + float64(0.9*diffuse))
+	if depth > 0 && reflect > 0 {
+		r := dir.sub(normal.scale(2 * dir.dot(normal)))
+		result = result.scale(1 - reflect).add(traceRay(hit.add(normal.scale(1e-4)), r, depth-1).scale(reflect))
+	}
+	return result
+}
+
+// RenderBenchmark 分块并行渲染测试
+type RenderBenchmark struct {
+	*BaseBenchmark
+	outputDir string
+}
+
+// NewRenderBenchmark 创建渲染测试实例，outputDir非空时将渲染结果保存为PNG
+func NewRenderBenchmark(outputDir string) *RenderBenchmark {
+	testFunc := func(workload int) {
+		for i := 0; i < workload; i++ {
+			for _, scene := range renderScenes {
+				_ = renderImage(scene, 1)
+			}
+		}
+	}
+
+	return &RenderBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"图形渲染测试（Mandelbrot & Ray Tracing）",
+			"测试Mandelbrot集合和光线追踪场景的分块渲染性能",
+			"浮点性能",
+			testFunc,
+			2, // 每个场景渲染2次
+		),
+		outputDir: outputDir,
+	}
+}
+
+// Run 执行基准测试，并比较单goroutine与proc个goroutine分块渲染的速度，校验像素哈希
+func (b *RenderBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		for _, scene := range renderScenes {
+			mpix := float64(scene.width*scene.height) / 1e6
+			start := time.Now()
+			single := renderImage(scene, 1)
+			singleTime := time.Since(start)
+			start = time.Now()
+			parallel := renderImage(scene, proc)
+			parallelTime := time.Since(start)
+			res.Metrics = append(res.Metrics,
+				BenchmarkMetric{Name: scene.name + "/单goroutine", Value: mpix / singleTime.Seconds(), Unit: "Mpix/s"},
+				BenchmarkMetric{Name: fmt.Sprintf("%s/%d goroutine", scene.name, proc), Value: mpix / parallelTime.Seconds(), Unit: "Mpix/s"},
+			)
+			hash := pixelHash(parallel)
+			status := "校验通过"
+			if hash != pixelHash(single) {
+				status = "与单goroutine渲染不一致"
+			} else if hash != scene.hash {
+				status = fmt.Sprintf("与参考值 %016x 不一致，可能存在浮点差异", scene.hash)
+			}
+			res.Notes = append(res.Notes, fmt.Sprintf("%s 像素哈希 %016x（%s）", scene.name, hash, status))
+			if b.outputDir != "" {
+				path := filepath.Join(b.outputDir, scene.file)
+				if err := writePNG(path, parallel); err != nil {
+					res.Notes = append(res.Notes, fmt.Sprintf("保存 %s 失败: %v", path, err))
+				} else {
+					res.Notes = append(res.Notes, fmt.Sprintf("已保存 %s", path))
+				}
+			}
+		}
+	})
+}
+
+// writePNG 将图像编码为PNG文件
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// schedulerTests 调度器微基准，每项执行n次操作并返回每次操作的耗时样本
+var schedulerTests = []struct {
+	name string
+	run  func(n int) []time.Duration
+}{
+	{"Goroutine创建退出", goroutineSpawnTest},
+	{"Gosched乒乓", goschedPingPongTest},
+	{"无缓冲通道往返", channelRoundTripTest},
+	{"Select汇聚(64路)", selectFanInTest},
+	{"休眠Goroutine唤醒", parkedWakeupTest},
+}
+
+// SchedulerBenchmark Goroutine调度器微基准测试
+type SchedulerBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewSchedulerBenchmark 创建调度器测试实例
+func NewSchedulerBenchmark() *SchedulerBenchmark {
+	testFunc := func(workload int) {
+		for _, test := range schedulerTests {
+			_ = test.run(workload)
+		}
+	}
+
+	return &SchedulerBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"调度器测试（Goroutine Scheduler）",
+			"测试Goroutine创建、让出、通道往返、Select汇聚和唤醒延迟",
+			"并发性能",
+			testFunc,
+			20000, // 每项2万次操作
+		),
+	}
+}
+
+// Run 执行基准测试，并统计各项操作耗时的分位数
+func (b *SchedulerBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		for _, test := range schedulerTests {
+			res.Metrics = append(res.Metrics, latencyMetrics(test.name, test.run(b.workload))...)
+		}
+	})
+}
+
+// goroutineSpawnTest 测量创建一个goroutine并等待其退出的耗时
+func goroutineSpawnTest(n int) []time.Duration {
+	samples := make([]time.Duration, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		wg.Add(1)
+		go func() {
+			wg.Done()
+		}()
+		wg.Wait()
+		samples[i] = time.Since(start)
+	}
+	return samples
+}
+
+// goschedPingPongTest 两个goroutine通过原子变量交替，等待期间调用runtime.Gosched让出
+func goschedPingPongTest(n int) []time.Duration {
+	samples := make([]time.Duration, n)
+	var turn int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			for atomic.LoadInt32(&turn) != 1 {
+				runtime.Gosched()
+			}
+			atomic.StoreInt32(&turn, 0)
+		}
+	}()
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		atomic.StoreInt32(&turn, 1)
+		for atomic.LoadInt32(&turn) != 0 {
+			runtime.Gosched()
+		}
+		samples[i] = time.Since(start)
+	}
+	<-done
+	return samples
+}
+
+// channelRoundTripTest 测量无缓冲通道上一次请求和应答的往返耗时
+func channelRoundTripTest(n int) []time.Duration {
+	samples := make([]time.Duration, n)
+	ping := make(chan int)
+	pong := make(chan int)
+	go func() {
+		for v := range ping {
+			pong <- v
+		}
+	}()
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		ping <- i
+		<-pong
+		samples[i] = time.Since(start)
+	}
+	close(ping)
+	return samples
+}
+
+// selectFanInTest 64个生产者经两级8路select汇聚到一个消费者，按每64条消息采样
+func selectFanInTest(n int) []time.Duration {
+	const fanIn = 8
+	const batch = fanIn * fanIn
+	perProducer := n / batch
+	if perProducer < 1 {
+		perProducer = 1
+	}
+	out := make(chan int)
+	var mergers [fanIn]chan int
+	for m := range mergers {
+		var inputs [fanIn]chan int
+		for p := range inputs {
+			inputs[p] = make(chan int)
+			go func(ch chan<- int) {
+				for i := 0; i < perProducer; i++ {
+					ch <- i
+				}
+				close(ch)
+			}(inputs[p])
+		}
+		mergers[m] = make(chan int)
+		go selectMerge(inputs, mergers[m])
+	}
+	go selectMerge(mergers, out)
+
+	samples := make([]time.Duration, 0, perProducer)
+	start := time.Now()
+	received := 0
+	for range out {
+		received++
+		if received%batch == 0 {
+			samples = append(samples, time.Since(start)/batch)
+			start = time.Now()
+		}
+	}
+	return samples
+}
+
+// selectMerge 使用select将8个输入通道汇聚到输出通道，全部关闭后关闭输出
+func selectMerge(in [8]chan int, out chan<- int) {
+	for open := len(in); open > 0; {
+		var idx, v int
+		var ok bool
+		select {
+		case v, ok = <-in[0]:
+			idx = 0
+		case v, ok = <-in[1]:
+			idx = 1
+		case v, ok = <-in[2]:
+			idx = 2
+		case v, ok = <-in[3]:
+			idx = 3
+		case v, ok = <-in[4]:
+			idx = 4
+		case v, ok = <-in[5]:
+			idx = 5
+		case v, ok = <-in[6]:
+			idx = 6
+		case v, ok = <-in[7]:
+			idx = 7
+		}
+		if !ok {
+			// 已关闭的通道置为nil，之后不再被选中
+			in[idx] = nil
+			open--
+			continue
+		}
+		out <- v
+	}
+	close(out)
+}
+
+// parkedWakeupTest 测量从发送信号到阻塞中的goroutine恢复运行的延迟
+func parkedWakeupTest(n int) []time.Duration {
+	samples := make([]time.Duration, n)
+	wake := make(chan time.Time)
+	woken := make(chan time.Duration)
+	var parked int32
+	go func() {
+		for {
+			atomic.StoreInt32(&parked, 1)
+			t, ok := <-wake
+			if !ok {
+				return
+			}
+			woken <- time.Since(t)
+		}
+	}()
+	for i := 0; i < n; i++ {
+		// 等待对方进入阻塞，再多让出几次以确保其已挂起
+		for atomic.LoadInt32(&parked) != 1 {
+			runtime.Gosched()
+		}
+		runtime.Gosched()
+		runtime.Gosched()
+		atomic.StoreInt32(&parked, 0)
+		wake <- time.Now()
+		samples[i] = <-woken
+	}
+	close(wake)
+	return samples
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"time"
+)
+
+// sciKernel 科学计算内核，run执行一次并校验结果，返回工作量（浮点运算次数或迭代次数）
+type sciKernel struct {
+	name string
+	unit string // GFLOPS 或 iter/s
+	run  func() (work float64, err error)
+}
+
+var sciKernels = []sciKernel{
+	{"FFT(2^16)", "GFLOPS", fftKernel},
+	{"N-body(5体)", "iter/s", nbodyKernel},
+	{"LU分解(256)", "GFLOPS", luKernel},
+	{"稀疏矩阵向量乘(CSR)", "GFLOPS", spmvKernel},
+	{"Jacobi迭代(256x256)", "GFLOPS", jacobiKernel},
+}
+
+// ScientificBenchmark 科学计算内核测试
+type ScientificBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewScientificBenchmark 创建科学计算测试实例
+func NewScientificBenchmark() *ScientificBenchmark {
+	testFunc := func(workload int) {
+		for _, kernel := range sciKernels {
+			for i := 0; i < workload; i++ {
+				_, _ = kernel.run()
+			}
+		}
+	}
+
+	return &ScientificBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"科学计算内核（Scientific Kernels）",
+			"测试FFT、N体模拟、LU分解、稀疏矩阵向量乘和Jacobi迭代性能",
+			"浮点性能",
+			testFunc,
+			4, // 每个内核执行4次
+		),
+	}
+}
+
+// Run 执行基准测试，并报告每个内核的GFLOPS或每秒迭代次数及校验结果
+func (b *ScientificBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		for _, kernel := range sciKernels {
+			total := 0.0
+			var err error
+			start := time.Now()
+			for i := 0; i < b.workload && err == nil; i++ {
+				var work float64
+				work, err = kernel.run()
+				total += work
+			}
+			if err != nil {
+				res.Notes = append(res.Notes, fmt.Sprintf("%s 校验失败: %v", kernel.name, err))
+				continue
+			}
+			rate := total / time.Since(start).Seconds()
+			if kernel.unit == "GFLOPS" {
+				rate /= 1e9
+			}
+			res.Metrics = append(res.Metrics, BenchmarkMetric{Name: kernel.name, Value: rate, Unit: kernel.unit})
+		}
+	})
+}
+
+// fftKernel 对单频余弦信号做正反变换，校验频谱峰值和往返误差
+func fftKernel() (float64, error) {
+	const n = 1 << 16
+	const freq = 1234
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(math.Cos(2*math.Pi*freq*float64(i)/n), 0)
+	}
+	orig := make([]complex128, n)
+	copy(orig, x)
+
+	fft(x, false)
+	// 余弦信号的频谱只在freq和n-freq处有幅值为n/2的峰
+	for _, k := range []int{freq, n - freq} {
+		if math.Abs(cmplx.Abs(x[k])-n/2) > 1e-6*n {
+			return 0, fmt.Errorf("频点%d幅值为%.3f，期望%d", k, cmplx.Abs(x[k]), n/2)
+		}
+	}
+	if cmplx.Abs(x[freq+1]) > 1e-6*n {
+		return 0, fmt.Errorf("频点%d出现泄漏", freq+1)
+	}
+	fft(x, true)
+	for i := range x {
+		if cmplx.Abs(x[i]-orig[i]) > 1e-9 {
+			return 0, fmt.Errorf("逆变换误差过大")
+		}
+	}
+	// 每次变换约5·n·log2(n)次浮点运算
+	return 2 * 5 * n * 16, nil
+}
+
+// fft 原地迭代式基2 FFT，inverse为true时计算逆变换并归一化
+func fft(x []complex128, inverse bool) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1.0
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			half := size / 2
+			for k := 0; k < half; k++ {
+				u := x[start+k]
+				v := x[start+k+half] * w
+				x[start+k] = u + v
+				x[start+k+half] = u - v
+				w *= step
+			}
+		}
+	}
+	if inverse {
+		scale := complex(1/float64(n), 0)
+		for i := range x {
+			x[i] *= scale
+		}
+	}
+}
+
+// nbodyBody 天体的位置、速度和质量（天文单位、年、太阳质量）
+type nbodyBody struct {
+	x, y, z, vx, vy, vz, mass float64
+}
+
+const (
+	solarMass   = 4 * math.Pi * math.Pi
+	daysPerYear = 365.24
+)
+
+// newJovianSystem 太阳和四颗类木行星的初始状态（与Computer Language Benchmarks Game一致）
+func newJovianSystem() []nbodyBody {
+	bodies := []nbodyBody{
+		{0, 0, 0, 0, 0, 0, solarMass},
+		{4.84143144246472090e+00, -1.16032004402742839e+00, -1.03622044471123109e-01,
+			1.66007664274403694e-03 * daysPerYear, 7.69901118419740425e-03 * daysPerYear, -6.90460016972063023e-05 * daysPerYear,
+			9.54791938424326609e-04 * solarMass},
+		{8.34336671824457987e+00, 4.12479856412430479e+00, -4.03523417114321381e-01,
+			-2.76742510726862411e-03 * daysPerYear, 4.99852801234917238e-03 * daysPerYear, 2.30417297573763929e-05 * daysPerYear,
+			2.85885980666130812e-04 * solarMass},
+		{1.28943695621391310e+01, -1.51111514016986312e+01, -2.23307578892655734e-01,
+			2.96460137564761618e-03 * daysPerYear, 2.37847173959480950e-03 * daysPerYear, -2.96589568540237556e-05 * daysPerYear,
+			4.36624404335156298e-05 * solarMass},
+		{1.53796971148509165e+01, -2.59193146099879641e+01, 1.79258772950371181e-01,
+			2.68067772490389322e-03 * daysPerYear, 1.62824170038242295e-03 * daysPerYear, -9.51592254519715870e-05 * daysPerYear,
+			5.15138902046611451e-05 * solarMass},
+	}
+	// 调整太阳速度使系统总动量为零
+	var px, py, pz float64
+	for _, b := range bodies {
+		px += b.vx * b.mass
+		py += b.vy * b.mass
+		pz += b.vz * b.mass
+	}
+	bodies[0].vx = -px / solarMass
+	bodies[0].vy = -py / solarMass
+	bodies[0].vz = -pz / solarMass
+	return bodies
+}
+
+func nbodyAdvance(bodies []nbodyBody, dt float64) {
+	for i := range bodies {
+		bi := &bodies[i]
+		for j := i + 1; j < len(bodies); j++ {
+			bj := &bodies[j]
+			dx, dy, dz := bi.x-bj.x, bi.y-bj.y, bi.z-bj.z
+			d2 := dx*dx + dy*dy + dz*dz
+			mag := dt / (d2 * math.Sqrt(d2))
+			bi.vx -= dx * bj.mass * mag
+			bi.vy -= dy * bj.mass * mag
+			bi.vz -= dz * bj.mass * mag
+			bj.vx += dx * bi.mass * mag
+			bj.vy += dy * bi.mass * mag
+			bj.vz += dz * bi.mass * mag
+		}
+	}
+	for i := range bodies {
+		b := &bodies[i]
+		b.x += dt * b.vx
+		b.y += dt * b.vy
+		b.z += dt * b.vz
+	}
+}
+
+func nbodyEnergy(bodies []nbodyBody) float64 {
+	e := 0.0
+	for i, b := range bodies {
+		e += 0.5 * b.mass * (b.vx*b.vx + b.vy*b.vy + b.vz*b.vz)
+		for j := i + 1; j < len(bodies); j++ {
+			o := bodies[j]
+			dx, dy, dz := b.x-o.x, b.y-o.y, b.z-o.z
+			e -= b.mass * o.mass / math.Sqrt(dx*dx+dy*dy+dz*dz)
+		}
+	}
+	return e
+}
+
+// nbodyKernel 模拟1000步，校验前后能量与参考值-0.169075164和-0.169087605一致
+func nbodyKernel() (float64, error) {
+	const steps = 1000
+	const rounds = 100
+	for r := 0; r < rounds; r++ {
+		bodies := newJovianSystem()
+		if e := nbodyEnergy(bodies); math.Abs(e-(-0.169075164)) > 1e-9 {
+			return 0, fmt.Errorf("初始能量为%.9f", e)
+		}
+		for i := 0; i < steps; i++ {
+			nbodyAdvance(bodies, 0.01)
+		}
+		if e := nbodyEnergy(bodies); math.Abs(e-(-0.169087605)) > 1e-9 {
+			return 0, fmt.Errorf("%d步后能量为%.9f", steps, e)
+		}
+	}
+	return steps * rounds, nil
+}
+
+// luKernel 对随机矩阵做部分主元LU分解并求解Ax=b，b由全1解构造，校验解的误差
+func luKernel() (float64, error) {
+	const n = 256
+	r := rand.New(rand.NewSource(42))
+	a := make([]float64, n*n)
+	for i := range a {
+		a[i] = r.Float64()*2 - 1
+	}
+	b := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			b[i] += a[i*n+j]
+		}
+	}
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	for k := 0; k < n; k++ {
+		// 选择第k列绝对值最大的元素作为主元
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i*n+k]) > math.Abs(a[p*n+k]) {
+				p = i
+			}
+		}
+		if a[p*n+k] == 0 {
+			return 0, fmt.Errorf("矩阵奇异")
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a[k*n+j], a[p*n+j] = a[p*n+j], a[k*n+j]
+			}
+			perm[k], perm[p] = perm[p], perm[k]
+		}
+		pivot := a[k*n+k]
+		for i := k + 1; i < n; i++ {
+			f := a[i*n+k] / pivot
+			a[i*n+k] = f
+			rowI := a[i*n+k+1 : (i+1)*n]
+			rowK := a[k*n+k+1 : (k+1)*n]
+			for j, v := range rowK {
+				rowI[j] -= f * v
+			}
+		}
+	}
+	// 前代和回代求解
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[perm[i]]
+		for j := 0; j < i; j++ {
+			sum -= a[i*n+j] * x[j]
+		}
+		x[i] = sum
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= a[i*n+j] * x[j]
+		}
+		x[i] = sum / a[i*n+i]
+	}
+	for i, v := range x {
+		if math.Abs(v-1) > 1e-8 {
+			return 0, fmt.Errorf("x[%d]=%.12f，期望1", i, v)
+		}
+	}
+	return 2.0 / 3.0 * n * n * n, nil
+}
+
+// spmvKernel 对二维五点拉普拉斯矩阵乘全1向量，结果之和应为4m
+func spmvKernel() (float64, error) {
+	const m = 512
+	const rounds = 10
+	n := m * m
+	rowPtr := make([]int, 0, n+1)
+	colIdx := make([]int, 0, 5*n)
+	values := make([]float64, 0, 5*n)
+	rowPtr = append(rowPtr, 0)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			row := i*m + j
+			if i > 0 {
+				colIdx, values = append(colIdx, row-m), append(values, -1)
+			}
+			if j > 0 {
+				colIdx, values = append(colIdx, row-1), append(values, -1)
+			}
+			colIdx, values = append(colIdx, row), append(values, 4)
+			if j < m-1 {
+				colIdx, values = append(colIdx, row+1), append(values, -1)
+			}
+			if i < m-1 {
+				colIdx, values = append(colIdx, row+m), append(values, -1)
+			}
+			rowPtr = append(rowPtr, len(colIdx))
+		}
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1
+	}
+	y := make([]float64, n)
+	for r := 0; r < rounds; r++ {
+		for row := 0; row < n; row++ {
+			sum := 0.0
+			for k := rowPtr[row]; k < rowPtr[row+1]; k++ {
+				sum += values[k] * x[colIdx[k]]
+			}
+			y[row] = sum
+		}
+	}
+	total := 0.0
+	for _, v := range y {
+		total += v
+	}
+	if total != 4*m {
+		return 0, fmt.Errorf("结果之和为%.1f，期望%d", total, 4*m)
+	}
+	return 2 * float64(len(values)) * rounds, nil
+}
+
+// jacobiKernel 以离散调和函数x²-y²叠加(1,1)特征模态扰动作为初值，
+// Jacobi迭代每次将扰动精确衰减cos(π/M)倍，校验迭代后的误差
+func jacobiKernel() (float64, error) {
+	const m = 258 // 含边界的网格大小
+	const iterations = 100
+	const eps = 1.0
+	M := float64(m - 1)
+	exact := func(i, j int) float64 {
+		x, y := float64(i)/M, float64(j)/M
+		return x*x - y*y
+	}
+	mode := func(i, j int) float64 {
+		return math.Sin(math.Pi*float64(i)/M) * math.Sin(math.Pi*float64(j)/M)
+	}
+	u := make([]float64, m*m)
+	next := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			u[i*m+j] = exact(i, j) + eps*mode(i, j)
+		}
+	}
+	copy(next, u)
+	for it := 0; it < iterations; it++ {
+		for i := 1; i < m-1; i++ {
+			for j := 1; j < m-1; j++ {
+				next[i*m+j] = 0.25 * (u[(i-1)*m+j] + u[(i+1)*m+j] + u[i*m+j-1] + u[i*m+j+1])
+			}
+		}
+		u, next = next, u
+	}
+	decay := eps * math.Pow(math.Cos(math.Pi/M), iterations)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if math.Abs(u[i*m+j]-exact(i, j)-decay*mode(i, j)) > 1e-9 {
+				return 0, fmt.Errorf("网格点(%d,%d)误差过大", i, j)
+			}
+		}
+	}
+	return 5 * float64((m-2)*(m-2)) * iterations, nil
+}
@@ -7,68 +7,84 @@ import (
 
 // ScoreCalculator 综合评分系统
 type ScoreCalculator struct {
-	categoryWeights map[string]float64
+	profile      *WeightProfile // 类别和测试项目的权重方案
+	showCombined bool           // 是否在报告中显示单核和多核加权得到的综合得分
 }
 
 // NewScoreCalculator 创建评分计算器
-func NewScoreCalculator() *ScoreCalculator {
+func NewScoreCalculator(profile *WeightProfile, showCombined bool) *ScoreCalculator {
 	return &ScoreCalculator{
-		categoryWeights: map[string]float64{
-			"计算密集型": 0.2,
-			"内存性能":  0.15,
-			"并发性能":  0.15,
-			"加密性能":  0.15,
-			"浮点性能":  0.15,
-			"压缩性能":  0.1,
-			"算法性能":  0.1,
-		},
+		profile:      profile,
+		showCombined: showCombined,
 	}
 }
 
-// CalculateTotal 计算综合得分
-func (sc *ScoreCalculator) CalculateTotal(results []BenchmarkResult) float64 {
-	categoryScores := make(map[string][]float64)
-	// 按类别分组得分
-	for _, result := range results {
-		categoryScores[result.Category] = append(categoryScores[result.Category], result.Score)
-	}
-	// 计算加权总分
-	totalScore := 0.0
-	for category, scores := range categoryScores {
-		if len(scores) == 0 {
-			continue
-		}
-		// 取该类别的平均分
-		categoryAvg := 0.0
-		for _, score := range scores {
-			categoryAvg += score
-		}
-		categoryAvg /= float64(len(scores))
-		// 应用权重
-		if weight, exists := sc.categoryWeights[category]; exists {
-			totalScore += categoryAvg * weight
+// scoreKind 从测试结果中选取一种得分
+type scoreKind func(result BenchmarkResult) float64
+
+func singleScoreOf(result BenchmarkResult) float64   { return result.SingleScore }
+func multiScoreOf(result BenchmarkResult) float64    { return result.MultiScore }
+func combinedScoreOf(result BenchmarkResult) float64 { return result.Score }
+
+// efficiencyOf 选取由单核和多核实测耗时计算的并行效率，按得分相同的权重汇总后1表示线性扩展
+func efficiencyOf(result BenchmarkResult) float64 { return result.Efficiency }
+
+// CalculateTotal 计算总分，即各类别得分的加权几何平均
+func (sc *ScoreCalculator) CalculateTotal(results []BenchmarkResult, kind scoreKind) float64 {
+	var scores, weights []float64
+	for _, category := range sc.resultCategories(results) {
+		if weight := sc.profile.Categories[category]; weight > 0 {
+			scores = append(scores, sc.GetCategoryScore(results, category, kind))
+			weights = append(weights, weight)
 		}
 	}
-
-	return totalScore
+	return geometricMean(scores, weights)
 }
 
-// GetCategoryScore 获取特定类别得分
-func (sc *ScoreCalculator) GetCategoryScore(results []BenchmarkResult, category string) float64 {
-	var scores []float64
+// GetCategoryScore 获取特定类别得分，即该类别各测试得分按测试权重的加权几何平均
+func (sc *ScoreCalculator) GetCategoryScore(results []BenchmarkResult, category string, kind scoreKind) float64 {
+	var scores, weights []float64
 	for _, result := range results {
-		if result.Category == category {
-			scores = append(scores, result.Score)
+		if sc.categoryOf(result) == category {
+			scores = append(scores, kind(result))
+			weights = append(weights, sc.profile.BenchmarkWeightOf(result.Name))
 		}
 	}
-	if len(scores) == 0 {
-		return 0
+	return geometricMean(scores, weights)
+}
+
+// categoryOf 返回测试结果在权重方案下所属的类别
+func (sc *ScoreCalculator) categoryOf(result BenchmarkResult) string {
+	return sc.profile.CategoryOf(result.Name, result.Category)
+}
+
+// missingCategories 返回权重大于0但本次没有任何测试结果的类别，如使用-category或-skip-io时
+func (sc *ScoreCalculator) missingCategories(results []BenchmarkResult) []string {
+	ran := make(map[string]bool)
+	for _, category := range sc.resultCategories(results) {
+		ran[category] = true
 	}
-	avg := 0.0
-	for _, score := range scores {
-		avg += score
+	var missing []string
+	for _, category := range sc.profile.sortedCategories() {
+		if sc.profile.Categories[category] > 0 && !ran[category] {
+			missing = append(missing, category)
+		}
 	}
-	return avg / float64(len(scores))
+	return missing
+}
+
+// resultCategories 按测试注册顺序返回结果所属的类别
+func (sc *ScoreCalculator) resultCategories(results []BenchmarkResult) []string {
+	seen := make(map[string]bool)
+	var categories []string
+	for _, result := range results {
+		category := sc.categoryOf(result)
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	return categories
 }
 
 // GenerateReport 生成性能报告
@@ -77,29 +93,75 @@ func (sc *ScoreCalculator) GenerateReport(results []BenchmarkResult) string {
 	// 基本信息
 	report.WriteString("=== GoHyperPi v2 性能测试报告 ===\n\n")
 
-	// 综合得分
-	totalScore := sc.CalculateTotal(results)
-	report.WriteString(fmt.Sprintf("综合得分: %.0f\n", totalScore))
+	// 总分
+	proc := 1
+	if len(results) > 0 {
+		proc = results[0].Proc
+	}
+	single := sc.CalculateTotal(results, singleScoreOf)
+	multi := sc.CalculateTotal(results, multiScoreOf)
+	report.WriteString(fmt.Sprintf("单核得分: %.0f（参考机 = %.0f）\n", single, referenceScore))
+	report.WriteString(fmt.Sprintf("多核得分: %.0f（%d 核）\n", multi, proc))
+	report.WriteString(fmt.Sprintf("多核扩展效率: %.1f%%\n", sc.CalculateTotal(results, efficiencyOf)*100))
+	if sc.showCombined {
+		report.WriteString(fmt.Sprintf("综合得分: %.0f（80%%单核 + 20%%多核）\n", sc.CalculateTotal(results, combinedScoreOf)))
+	}
+	report.WriteString(fmt.Sprintf("参考机: %s（%s）\n", getReference().Machine, getReference().Flags))
+	report.WriteString(fmt.Sprintf("权重方案: %s", sc.profile.Name))
+	if sc.profile.Description != "" {
+		report.WriteString(fmt.Sprintf("（%s）", sc.profile.Description))
+	}
+	report.WriteString("\n")
+	if missing := sc.missingCategories(results); len(missing) > 0 {
+		report.WriteString(fmt.Sprintf("未运行的类别: %s（总分按其余类别的权重重新归一化）\n", strings.Join(missing, ", ")))
+	}
 	report.WriteString("\n")
 	// 分类得分
 	report.WriteString("分类得分:\n")
-	categories := []string{"计算密集型", "内存性能", "并发性能", "加密性能", "浮点性能", "压缩性能", "算法性能"}
-	for _, category := range categories {
-		score := sc.GetCategoryScore(results, category)
-		weight := sc.categoryWeights[category] * 100
-		report.WriteString(fmt.Sprintf("  %-6s: %8.0f (权重: %.0f%%)\n", category, score, weight))
+	for _, category := range sc.resultCategories(results) {
+		single := sc.GetCategoryScore(results, category, singleScoreOf)
+		multi := sc.GetCategoryScore(results, category, multiScoreOf)
+		weight := sc.profile.Categories[category] * 100
+		report.WriteString(fmt.Sprintf("  %s: 单核 %6.0f | 多核 %7.0f | 扩展效率 %5.1f%% (权重: %.4g%%)\n",
+			padRight(category, 8), single, multi, sc.GetCategoryScore(results, category, efficiencyOf)*100, weight))
 	}
 	report.WriteString("\n")
 	// 详细结果
 	report.WriteString("详细测试结果:\n")
 	for _, result := range results {
-		report.WriteString(fmt.Sprintf("  %-6s | %-32s | 得分: %8.0f | 单核耗时: %s | 多核耗时: %s | 多核/单核: %.2f\n",
-			result.Category, result.Name,
-			result.Score,
+		line := fmt.Sprintf("  %s | %s | 单核: %6.0f | 多核: %7.0f",
+			padRight(sc.categoryOf(result), 8), padRight(result.Name, 40),
+			result.SingleScore, result.MultiScore)
+		if sc.showCombined {
+			line += fmt.Sprintf(" | 综合: %6.0f", result.Score)
+		}
+		report.WriteString(fmt.Sprintf("%s | 单核耗时: %s | 多核耗时: %s | 加速比: %5.2fx | 并行效率: %5.1f%%\n",
+			line,
 			formatDuration(result.SingleDuration.Seconds()), formatDuration(result.MultiDuration.Seconds()),
-			result.Ratio))
+			result.Ratio, result.Efficiency*100))
 	}
 	report.WriteString("\n")
+	// 附加指标
+	hasMetrics := false
+	for _, result := range results {
+		if len(result.Metrics) == 0 && len(result.Notes) == 0 {
+			continue
+		}
+		if !hasMetrics {
+			report.WriteString("附加指标:\n")
+			hasMetrics = true
+		}
+		report.WriteString(fmt.Sprintf("  %s:\n", result.Name))
+		for _, metric := range result.Metrics {
+			report.WriteString(fmt.Sprintf("    %s: %12.2f %s\n", padRight(metric.Name, 24), metric.Value, metric.Unit))
+		}
+		for _, note := range result.Notes {
+			report.WriteString(fmt.Sprintf("    %s\n", note))
+		}
+	}
+	if hasMetrics {
+		report.WriteString("\n")
+	}
 
 	return report.String()
 }
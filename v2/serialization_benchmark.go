@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"time"
+)
+
+// serialDocument 序列化测试使用的嵌套文档
+type serialDocument struct {
+	XMLName xml.Name      `json:"-" xml:"document"`
+	Source  string        `json:"source" xml:"source,attr"`
+	Version int           `json:"version" xml:"version,attr"`
+	Orders  []serialOrder `json:"orders" xml:"order"`
+}
+
+type serialOrder struct {
+	ID       int64          `json:"id" xml:"id,attr"`
+	Customer serialCustomer `json:"customer" xml:"customer"`
+	Items    []serialItem   `json:"items" xml:"item"`
+	Tags     []string       `json:"tags" xml:"tag"`
+	Paid     bool           `json:"paid" xml:"paid"`
+	Total    float64        `json:"total" xml:"total"`
+}
+
+type serialCustomer struct {
+	Name    string `json:"name" xml:"name"`
+	Email   string `json:"email" xml:"email"`
+	Country string `json:"country" xml:"country"`
+	Level   int    `json:"level" xml:"level"`
+}
+
+type serialItem struct {
+	SKU      string  `json:"sku" xml:"sku,attr"`
+	Title    string  `json:"title" xml:"title"`
+	Quantity int     `json:"quantity" xml:"quantity"`
+	Price    float64 `json:"price" xml:"price"`
+}
+
+// newSerialDocument 生成包含100个订单的确定性文档
+func newSerialDocument() *serialDocument {
+	r := rand.New(rand.NewSource(42))
+	countries := []string{"CN", "US", "DE", "JP", "BR"}
+	doc := &serialDocument{Source: "GoHyperPi", Version: 2}
+	for i := 0; i < 100; i++ {
+		order := serialOrder{
+			ID: int64(100000 + i),
+			Customer: serialCustomer{
+				Name:    fmt.Sprintf("customer-%d", r.Intn(10000)),
+				Email:   fmt.Sprintf("user%d@example.com", r.Intn(10000)),
+				Country: countries[r.Intn(len(countries))],
+				Level:   r.Intn(5),
+			},
+			Tags: []string{"priority", "gift", "express"}[:r.Intn(4)],
+			Paid: r.Intn(2) == 0,
+		}
+		for j := r.Intn(5) + 1; j > 0; j-- {
+			item := serialItem{
+				SKU:      fmt.Sprintf("SKU-%06d", r.Intn(1000000)),
+				Title:    fmt.Sprintf("商品 %d 号", r.Intn(1000)),
+				Quantity: r.Intn(10) + 1,
+				Price:    float64(r.Intn(100000)) / 100,
+			}
+			order.Total += item.Price * float64(item.Quantity)
+			order.Items = append(order.Items, item)
+		}
+		doc.Orders = append(doc.Orders, order)
+	}
+	return doc
+}
+
+// serialCodec 序列化编解码器，newCase返回编码和解码单条消息的函数
+type serialCodec struct {
+	name    string
+	newCase func() (encode func() []byte, decode func(data []byte))
+}
+
+var (
+	jsonCodec = serialCodec{"JSON", func() (func() []byte, func([]byte)) {
+		doc := newSerialDocument()
+		encode := func() []byte {
+			data, _ := json.Marshal(doc)
+			return data
+		}
+		decode := func(data []byte) {
+			var out serialDocument
+			_ = json.Unmarshal(data, &out)
+		}
+		return encode, decode
+	}}
+	gobCodec = serialCodec{"Gob", func() (func() []byte, func([]byte)) {
+		doc := newSerialDocument()
+		encode := func() []byte {
+			var buf bytes.Buffer
+			_ = gob.NewEncoder(&buf).Encode(doc)
+			return buf.Bytes()
+		}
+		decode := func(data []byte) {
+			var out serialDocument
+			_ = gob.NewDecoder(bytes.NewReader(data)).Decode(&out)
+		}
+		return encode, decode
+	}}
+	xmlCodec = serialCodec{"XML", func() (func() []byte, func([]byte)) {
+		doc := newSerialDocument()
+		encode := func() []byte {
+			data, _ := xml.Marshal(doc)
+			return data
+		}
+		decode := func(data []byte) {
+			var out serialDocument
+			_ = xml.Unmarshal(data, &out)
+		}
+		return encode, decode
+	}}
+	base64Codec = serialCodec{"Base64", func() (func() []byte, func([]byte)) {
+		payload := make([]byte, 64*1024)
+		_, _ = rand.New(rand.NewSource(42)).Read(payload)
+		encode := func() []byte {
+			return []byte(base64.StdEncoding.EncodeToString(payload))
+		}
+		decode := func(data []byte) {
+			_, _ = base64.StdEncoding.DecodeString(string(data))
+		}
+		return encode, decode
+	}}
+	varintCodec = serialCodec{"Varint", func() (func() []byte, func([]byte)) {
+		// 数值大小呈对数分布，覆盖1到10字节的各种编码长度
+		r := rand.New(rand.NewSource(42))
+		values := make([]uint64, 8192)
+		for i := range values {
+			values[i] = r.Uint64() >> uint(r.Intn(64))
+		}
+		encode := func() []byte {
+			buf := make([]byte, 0, len(values)*binary.MaxVarintLen64)
+			for _, v := range values {
+				buf = binary.AppendUvarint(buf, v)
+			}
+			return buf
+		}
+		decode := func(data []byte) {
+			out := make([]uint64, 0, len(values))
+			for len(data) > 0 {
+				v, n := binary.Uvarint(data)
+				if n <= 0 {
+					return
+				}
+				out = append(out, v)
+				data = data[n:]
+			}
+		}
+		return encode, decode
+	}}
+)
+
+// SerializationBenchmark 序列化性能测试，每个实例测试一种编解码器
+type SerializationBenchmark struct {
+	*BaseBenchmark
+	codec serialCodec
+}
+
+func newSerializationBenchmark(codec serialCodec, name, description string, workload int) *SerializationBenchmark {
+	testFunc := func(workload int) {
+		encode, decode := codec.newCase()
+		for i := 0; i < workload; i++ {
+			decode(encode())
+		}
+	}
+
+	return &SerializationBenchmark{
+		BaseBenchmark: NewBaseBenchmark(name, description, "序列化性能", testFunc, workload),
+		codec:         codec,
+	}
+}
+
+// NewJSONBenchmark 创建JSON序列化测试实例
+func NewJSONBenchmark() *SerializationBenchmark {
+	return newSerializationBenchmark(jsonCodec,
+		"JSON序列化（encoding/json）",
+		"测试嵌套结构体的JSON编码和解码性能",
+		150, // 150次编解码
+	)
+}
+
+// NewGobBenchmark 创建Gob序列化测试实例
+func NewGobBenchmark() *SerializationBenchmark {
+	return newSerializationBenchmark(gobCodec,
+		"Gob序列化（encoding/gob）",
+		"测试嵌套结构体的Gob编码和解码性能",
+		300, // 300次编解码
+	)
+}
+
+// NewXMLBenchmark 创建XML序列化测试实例
+func NewXMLBenchmark() *SerializationBenchmark {
+	return newSerializationBenchmark(xmlCodec,
+		"XML序列化（encoding/xml）",
+		"测试嵌套结构体的XML编码和解码性能",
+		50, // 50次编解码
+	)
+}
+
+// NewBase64Benchmark 创建Base64编解码测试实例
+func NewBase64Benchmark() *SerializationBenchmark {
+	return newSerializationBenchmark(base64Codec,
+		"Base64编解码（encoding/base64）",
+		"测试64KB二进制数据的Base64编码和解码性能",
+		500, // 500次编解码
+	)
+}
+
+// NewVarintBenchmark 创建Varint编解码测试实例
+func NewVarintBenchmark() *SerializationBenchmark {
+	return newSerializationBenchmark(varintCodec,
+		"Varint编解码（encoding/binary）",
+		"测试8192个整数的变长编码和解码性能",
+		500, // 500次编解码
+	)
+}
+
+// Run 执行基准测试，并分别测量编码和解码的吞吐量与每次操作的内存分配次数
+func (b *SerializationBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		encode, decode := b.codec.newCase()
+		data := encode()
+		mb := float64(len(data)) * float64(b.workload) / 1024 / 1024
+
+		encodeTime, encodeAllocs := measureAllocs(b.workload, func() { _ = encode() })
+		decodeTime, decodeAllocs := measureAllocs(b.workload, func() { decode(data) })
+		res.Metrics = append(res.Metrics,
+			BenchmarkMetric{Name: "编码", Value: mb / encodeTime.Seconds(), Unit: "MB/s"},
+			BenchmarkMetric{Name: "解码", Value: mb / decodeTime.Seconds(), Unit: "MB/s"},
+			BenchmarkMetric{Name: "编码内存分配", Value: encodeAllocs, Unit: "allocs/op"},
+			BenchmarkMetric{Name: "解码内存分配", Value: decodeAllocs, Unit: "allocs/op"},
+		)
+		res.Notes = append(res.Notes, fmt.Sprintf("单条消息编码后 %s", formatBytes(len(data))))
+	})
+}
+
+// measureAllocs 执行n次fn，返回总耗时和平均每次的内存分配次数
+func measureAllocs(n int, fn func()) (time.Duration, float64) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		fn()
+	}
+	d := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return d, float64(after.Mallocs-before.Mallocs) / float64(n)
+}
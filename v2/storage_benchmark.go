@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// storageStats 一次存储测试的结果
+type storageStats struct {
+	fileSize    int
+	cacheErr    error // 读取前丢弃页缓存失败的原因，为nil时读取来自存储设备
+	writeTime   time.Duration
+	readTime    time.Duration
+	randomReads []time.Duration
+	fsyncs      []time.Duration
+	smallFiles  int
+	createTime  time.Duration
+	statTime    time.Duration
+	deleteTime  time.Duration
+}
+
+const (
+	storageChunkSize   = 1024 * 1024 // 顺序读写的块大小
+	storageBlockSize   = 4096        // 随机读和fsync的块大小
+	storageRandomReads = 4096
+	storageFsyncs      = 50
+	storageSmallFiles  = 500
+)
+
+// storageTest 在parent下的临时目录中执行各项存储测试，结束后删除临时目录
+func storageTest(parent string, fileMB int) (stats storageStats, err error) {
+	dir, err := createTempDir(parent, "gohyperpi-io-*")
+	if err != nil {
+		return stats, err
+	}
+	defer removeTempDir(dir)
+
+	stats.fileSize = fileMB * 1024 * 1024
+	path := filepath.Join(dir, "sequential.dat")
+	chunk := make([]byte, storageChunkSize)
+	_, _ = rand.New(rand.NewSource(42)).Read(chunk)
+
+	// 顺序写入，计时包含最后的fsync
+	start := time.Now()
+	f, err := os.Create(path)
+	if err != nil {
+		return stats, err
+	}
+	for written := 0; written < stats.fileSize; written += len(chunk) {
+		if _, err = f.Write(chunk); err != nil {
+			_ = f.Close()
+			return stats, err
+		}
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return stats, err
+	}
+	if err = f.Close(); err != nil {
+		return stats, err
+	}
+	stats.writeTime = time.Since(start)
+
+	// 顺序读取，先丢弃页缓存以免测到内存速度
+	if f, err = os.Open(path); err != nil {
+		return stats, err
+	}
+	defer f.Close()
+	stats.cacheErr = dropPageCache(f)
+	start = time.Now()
+	for {
+		n, readErr := f.Read(chunk)
+		if n == 0 || readErr != nil {
+			break
+		}
+	}
+	stats.readTime = time.Since(start)
+
+	// 随机4KB读取，顺序读取已将文件读入页缓存，需要再次丢弃
+	if stats.cacheErr == nil {
+		stats.cacheErr = dropPageCache(f)
+	}
+	r := rand.New(rand.NewSource(42))
+	block := make([]byte, storageBlockSize)
+	blocks := stats.fileSize / storageBlockSize
+	stats.randomReads = make([]time.Duration, storageRandomReads)
+	for i := range stats.randomReads {
+		offset := int64(r.Intn(blocks)) * storageBlockSize
+		t := time.Now()
+		if _, err = f.ReadAt(block, offset); err != nil {
+			return stats, err
+		}
+		stats.randomReads[i] = time.Since(t)
+	}
+
+	// 每次写入4KB后fsync
+	syncFile, err := os.Create(filepath.Join(dir, "fsync.dat"))
+	if err != nil {
+		return stats, err
+	}
+	defer syncFile.Close()
+	stats.fsyncs = make([]time.Duration, storageFsyncs)
+	for i := range stats.fsyncs {
+		t := time.Now()
+		if _, err = syncFile.Write(block); err != nil {
+			return stats, err
+		}
+		if err = syncFile.Sync(); err != nil {
+			return stats, err
+		}
+		stats.fsyncs[i] = time.Since(t)
+	}
+
+	// 小文件创建、查询和删除
+	stats.smallFiles = storageSmallFiles
+	names := make([]string, storageSmallFiles)
+	for i := range names {
+		names[i] = filepath.Join(dir, fmt.Sprintf("small-%04d.dat", i))
+	}
+	start = time.Now()
+	for _, name := range names {
+		if err = os.WriteFile(name, block[:1024], 0644); err != nil {
+			return stats, err
+		}
+	}
+	stats.createTime = time.Since(start)
+	start = time.Now()
+	for _, name := range names {
+		if _, err = os.Stat(name); err != nil {
+			return stats, err
+		}
+	}
+	stats.statTime = time.Since(start)
+	start = time.Now()
+	for _, name := range names {
+		if err = os.Remove(name); err != nil {
+			return stats, err
+		}
+	}
+	stats.deleteTime = time.Since(start)
+	return stats, nil
+}
+
+// StorageBenchmark 文件读写性能测试
+type StorageBenchmark struct {
+	*BaseBenchmark
+	dir    string
+	taskMB int // 单个任务的测试文件大小，多核测试时由各任务分摊workload
+}
+
+// storageMinTaskMB 多核测试时单个任务的最小测试文件大小
+const storageMinTaskMB = 4
+
+// NewStorageBenchmark 创建存储测试实例，dir为空时使用系统临时目录
+func NewStorageBenchmark(dir string) *StorageBenchmark {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	b := &StorageBenchmark{dir: dir}
+	testFunc := func(int) {
+		_, err := storageTest(dir, b.taskMB)
+		b.recordError(err)
+	}
+	b.BaseBenchmark = NewBaseBenchmark(
+		"文件读写测试（File I/O）",
+		"测试顺序读写吞吐、随机4KB读、fsync延迟和小文件操作",
+		"存储性能",
+		testFunc,
+		64, // 64MB测试文件
+	)
+	b.taskMB = b.workload
+	// 多核测试的并发任务分摊测试文件大小，总写入量仍约为workload
+	b.shareBudget = func(tasks int) {
+		b.taskMB = b.taskMBFor(tasks)
+	}
+	return b
+}
+
+// taskMBFor 返回tasks个并发任务分摊workload后单个任务的测试文件大小
+func (b *StorageBenchmark) taskMBFor(tasks int) int {
+	n := b.workload / tasks
+	if n < storageMinTaskMB {
+		n = storageMinTaskMB
+	}
+	return minInt(n, b.workload)
+}
+
+// Run 执行基准测试，并报告吞吐量、IOPS和延迟分位数
+func (b *StorageBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		stats, err := storageTest(b.dir, b.workload)
+		if err != nil {
+			// 计分测试已出错时fillResult已说明原因
+			if b.testError() == nil {
+				skipScoring(res, fmt.Sprintf("存储测试失败: %v", err))
+			}
+			return
+		}
+		mb := float64(stats.fileSize) / 1024 / 1024
+		var randomTotal time.Duration
+		for _, d := range stats.randomReads {
+			randomTotal += d
+		}
+		files := float64(stats.smallFiles)
+		res.Metrics = append(res.Metrics,
+			BenchmarkMetric{Name: "顺序写入", Value: mb / stats.writeTime.Seconds(), Unit: "MB/s"},
+			BenchmarkMetric{Name: "顺序读取", Value: mb / stats.readTime.Seconds(), Unit: "MB/s"},
+			BenchmarkMetric{Name: "随机4KB读", Value: float64(len(stats.randomReads)) / randomTotal.Seconds(), Unit: "IOPS"},
+		)
+		res.Metrics = append(res.Metrics, latencyMetrics("随机4KB读延迟", stats.randomReads)...)
+		res.Metrics = append(res.Metrics, latencyMetrics("fsync延迟", stats.fsyncs)...)
+		res.Metrics = append(res.Metrics,
+			BenchmarkMetric{Name: "小文件创建", Value: files / stats.createTime.Seconds(), Unit: "files/s"},
+			BenchmarkMetric{Name: "小文件查询", Value: files / stats.statTime.Seconds(), Unit: "files/s"},
+			BenchmarkMetric{Name: "小文件删除", Value: files / stats.deleteTime.Seconds(), Unit: "files/s"},
+		)
+		res.Notes = append(res.Notes, fmt.Sprintf("测试目录 %s，多核测试每个任务 %dMB", b.dir, b.taskMBFor(proc*times)))
+		if stats.cacheErr != nil {
+			res.Notes = append(res.Notes, fmt.Sprintf("读取可能命中操作系统页缓存，不代表存储设备速度（%v）", stats.cacheErr))
+		} else {
+			res.Notes = append(res.Notes, "读取前已丢弃测试文件的页缓存")
+		}
+	})
+}
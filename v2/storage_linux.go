@@ -0,0 +1,29 @@
+//go:build linux && (amd64 || arm64 || riscv64 || ppc64 || ppc64le)
+
+package main
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+const (
+	posixFadvDontNeed = 4          // POSIX_FADV_DONTNEED
+	tmpfsMagic        = 0x01021994 // statfs返回的tmpfs文件系统类型
+)
+
+// dropPageCache 写回文件的脏页后通知内核丢弃其页缓存，使后续读取来自存储设备
+func dropPageCache(f *os.File) error {
+	var fs syscall.Statfs_t
+	if err := syscall.Fstatfs(int(f.Fd()), &fs); err == nil && fs.Type == tmpfsMagic {
+		return errors.New("测试目录位于tmpfs，数据常驻内存")
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if _, _, errno := syscall.Syscall6(syscall.SYS_FADVISE64, f.Fd(), 0, 0, posixFadvDontNeed, 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
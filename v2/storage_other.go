@@ -0,0 +1,13 @@
+//go:build !(linux && (amd64 || arm64 || riscv64 || ppc64 || ppc64le))
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// dropPageCache 当前平台不支持丢弃单个文件的页缓存
+func dropPageCache(f *os.File) error {
+	return errors.New("当前平台不支持丢弃页缓存")
+}
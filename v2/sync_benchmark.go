@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// syncScoreGoroutines 计分时使用的固定竞争goroutine数，保证不同机器间可比
+const syncScoreGoroutines = 4
+
+// syncPrimitive 同步原语测试项，run在goroutines个goroutine间分摊ops次操作
+type syncPrimitive struct {
+	name string
+	run  func(goroutines, ops int)
+}
+
+// syncPrimitives 参与对比的同步原语
+var syncPrimitives = []syncPrimitive{
+	{"Mutex", mutexTest},
+	{"RWMutex读多", func(goroutines, ops int) { rwMutexTest(goroutines, ops, 9) }},
+	{"RWMutex写多", func(goroutines, ops int) { rwMutexTest(goroutines, ops, 1) }},
+	{"Atomic Add", atomicAddTest},
+	{"Atomic CAS", atomicCASTest},
+	{"Cond", condTest},
+	{"Channel信号量", channelSemaphoreTest},
+}
+
+// SyncPrimitiveBenchmark 同步原语对比测试
+type SyncPrimitiveBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewSyncPrimitiveBenchmark 创建同步原语对比测试实例
+func NewSyncPrimitiveBenchmark() *SyncPrimitiveBenchmark {
+	testFunc := func(workload int) {
+		for _, primitive := range syncPrimitives {
+			primitive.run(syncScoreGoroutines, workload)
+		}
+	}
+
+	return &SyncPrimitiveBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"同步原语对比（Sync Primitives）",
+			"测试Mutex、RWMutex、原子操作、Cond和通道信号量在不同竞争程度下的性能",
+			"并发性能",
+			testFunc,
+			100000, // 每种原语10万次操作
+		),
+	}
+}
+
+// Run 执行基准测试，并在1..proc个竞争goroutine下测量每种原语的单次操作耗时
+func (b *SyncPrimitiveBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		for _, goroutines := range contentionLevels(proc) {
+			for _, primitive := range syncPrimitives {
+				start := time.Now()
+				primitive.run(goroutines, b.workload)
+				ns := float64(time.Since(start).Nanoseconds()) / float64(b.workload)
+				res.Metrics = append(res.Metrics, BenchmarkMetric{
+					Name:  fmt.Sprintf("%s/%d", primitive.name, goroutines),
+					Value: ns,
+					Unit:  "ns/op",
+				})
+			}
+		}
+	})
+}
+
+// contentionLevels 返回1、2、4...直到proc的竞争级别
+func contentionLevels(proc int) []int {
+	var levels []int
+	for n := 1; n < proc; n *= 2 {
+		levels = append(levels, n)
+	}
+	return append(levels, proc)
+}
+
+// runContended 启动goroutines个goroutine，每个执行ops/goroutines次fn
+func runContended(goroutines, ops int, fn func(id, n int)) {
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(id int) {
+			defer wg.Done()
+			fn(id, ops/goroutines)
+		}(g)
+	}
+	wg.Wait()
+}
+
+func mutexTest(goroutines, ops int) {
+	var mu sync.Mutex
+	counter := 0
+	runContended(goroutines, ops, func(_, n int) {
+		for i := 0; i < n; i++ {
+			mu.Lock()
+			counter++
+			mu.Unlock()
+		}
+	})
+}
+
+// rwMutexTest 每10次操作中有reads次读锁，其余为写锁
+func rwMutexTest(goroutines, ops, reads int) {
+	var mu sync.RWMutex
+	counter := 0
+	runContended(goroutines, ops, func(_, n int) {
+		sum := 0
+		for i := 0; i < n; i++ {
+			if i%10 < reads {
+				mu.RLock()
+				sum += counter
+				mu.RUnlock()
+			} else {
+				mu.Lock()
+				counter++
+				mu.Unlock()
+			}
+		}
+		_ = sum
+	})
+}
+
+func atomicAddTest(goroutines, ops int) {
+	var counter int64
+	runContended(goroutines, ops, func(_, n int) {
+		for i := 0; i < n; i++ {
+			atomic.AddInt64(&counter, 1)
+		}
+	})
+}
+
+func atomicCASTest(goroutines, ops int) {
+	var counter int64
+	runContended(goroutines, ops, func(_, n int) {
+		for i := 0; i < n; i++ {
+			for {
+				old := atomic.LoadInt64(&counter)
+				if atomic.CompareAndSwapInt64(&counter, old, old+1) {
+					break
+				}
+			}
+		}
+	})
+}
+
+// condTest goroutine按令牌环轮流执行，每次交接通过Broadcast唤醒等待者
+func condTest(goroutines, ops int) {
+	var mu sync.Mutex
+	cond := sync.NewCond(&mu)
+	turn := 0
+	runContended(goroutines, ops, func(id, n int) {
+		for i := 0; i < n; i++ {
+			mu.Lock()
+			for turn%goroutines != id {
+				cond.Wait()
+			}
+			turn++
+			cond.Broadcast()
+			mu.Unlock()
+		}
+	})
+}
+
+// channelSemaphoreTest 使用容量为1的通道作为信号量保护计数器
+func channelSemaphoreTest(goroutines, ops int) {
+	sem := make(chan struct{}, 1)
+	counter := 0
+	runContended(goroutines, ops, func(_, n int) {
+		for i := 0; i < n; i++ {
+			sem <- struct{}{}
+			counter++
+			<-sem
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/klauspost/cpuid/v2"
+)
+
+// syscallCostTests 廉价系统调用测试，每项执行n次并返回总耗时，返回0表示当前平台不支持
+var syscallCostTests = []struct {
+	name string
+	run  func(n int) time.Duration
+}{
+	{"getpid", getpidTest},
+	{"time.Now", timeNowTest},
+	{"管道零字节读", zeroByteReadTest},
+}
+
+// contextSwitchTests 线程切换测试，每项执行n次往返并返回每次往返的耗时样本
+var contextSwitchTests = []struct {
+	name string
+	run  func(n int) []time.Duration
+}{
+	{"管道乒乓(锁定线程)", pipePingPongTest},
+	{"LockOSThread切换", lockedThreadSwitchTest},
+}
+
+// SyscallBenchmark 系统调用和上下文切换开销测试
+type SyscallBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewSyscallBenchmark 创建系统调用测试实例
+func NewSyscallBenchmark() *SyscallBenchmark {
+	testFunc := func(workload int) {
+		for _, test := range syscallCostTests {
+			_ = test.run(workload)
+		}
+		for _, test := range contextSwitchTests {
+			_ = test.run(workload / 50)
+		}
+	}
+
+	return &SyscallBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"系统调用测试（Syscall & Context Switch）",
+			"测试廉价系统调用、管道乒乓和锁定线程间切换的开销",
+			"并发性能",
+			testFunc,
+			200000, // 每项20万次调用，线程切换4000次往返
+		),
+	}
+}
+
+// Run 执行基准测试，并报告每次调用的耗时、切换延迟分位数和CPU漏洞缓解状态
+func (b *SyscallBenchmark) Run(proc, times int) BenchmarkResult {
+	return b.runWithMetrics(proc, times, func(res *BenchmarkResult) {
+		for _, test := range syscallCostTests {
+			d := test.run(b.workload)
+			if d == 0 {
+				res.Notes = append(res.Notes, fmt.Sprintf("%s: 当前平台不支持", test.name))
+				continue
+			}
+			res.Metrics = append(res.Metrics, BenchmarkMetric{Name: test.name, Value: float64(d.Nanoseconds()) / float64(b.workload), Unit: "ns/op"})
+		}
+		for _, test := range contextSwitchTests {
+			res.Metrics = append(res.Metrics, latencyMetrics(test.name, test.run(b.workload/50))...)
+		}
+		res.Notes = append(res.Notes, mitigationNotes()...)
+	})
+}
+
+// mitigationNotes 列出CPU支持的推测执行缓解特性，Linux下附带内核实际启用的缓解措施
+func mitigationNotes() []string {
+	var flags []string
+	for _, f := range []cpuid.FeatureID{cpuid.IBPB, cpuid.IBRS, cpuid.STIBP, cpuid.SPEC_CTRL_SSBD, cpuid.MD_CLEAR} {
+		if cpuid.CPU.Supports(f) {
+			flags = append(flags, f.String())
+		}
+	}
+	if len(flags) == 0 {
+		flags = append(flags, "无")
+	}
+	notes := []string{"CPU缓解特性: " + strings.Join(flags, ", ")}
+	for _, name := range []string{"meltdown", "spectre_v2", "mds"} {
+		data, err := os.ReadFile("/sys/devices/system/cpu/vulnerabilities/" + name)
+		if err != nil {
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("内核缓解 %s: %s", name, strings.TrimSpace(string(data))))
+	}
+	return notes
+}
+
+// getpidTest 执行n次getpid系统调用
+func getpidTest(n int) time.Duration {
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		_ = syscall.Getpid()
+	}
+	return time.Since(start)
+}
+
+// timeNowTest 执行n次time.Now，Linux下通常经vDSO调用clock_gettime
+func timeNowTest(n int) time.Duration {
+	var last time.Time
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		last = time.Now()
+	}
+	_ = last
+	return time.Since(start)
+}
+
+// pipePingPongTest 两个锁定到OS线程的goroutine经两条管道传递1字节，每次往返至少两次线程切换
+func pipePingPongTest(n int) []time.Duration {
+	r1, w1, err := os.Pipe()
+	if err != nil {
+		return nil
+	}
+	defer r1.Close()
+	defer w1.Close()
+	r2, w2, err := os.Pipe()
+	if err != nil {
+		return nil
+	}
+	defer r2.Close()
+	defer w2.Close()
+
+	done := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(done)
+		var buf [1]byte
+		for i := 0; i < n; i++ {
+			if _, err := r1.Read(buf[:]); err != nil {
+				return
+			}
+			if _, err := w2.Write(buf[:]); err != nil {
+				return
+			}
+		}
+	}()
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	samples := make([]time.Duration, 0, n)
+	var buf [1]byte
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		if _, err := w1.Write(buf[:]); err != nil {
+			break
+		}
+		if _, err := r2.Read(buf[:]); err != nil {
+			break
+		}
+		samples = append(samples, time.Since(start))
+	}
+	<-done
+	return samples
+}
+
+// lockedThreadSwitchTest 两个锁定到OS线程的goroutine经无缓冲通道往返，调度器必须唤醒另一个线程
+func lockedThreadSwitchTest(n int) []time.Duration {
+	ping := make(chan struct{})
+	pong := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		for range ping {
+			pong <- struct{}{}
+		}
+	}()
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	samples := make([]time.Duration, n)
+	for i := range samples {
+		start := time.Now()
+		ping <- struct{}{}
+		<-pong
+		samples[i] = time.Since(start)
+	}
+	close(ping)
+	return samples
+}
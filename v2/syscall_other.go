@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "time"
+
+// zeroByteReadTest 当前平台不支持对管道执行零字节read
+func zeroByteReadTest(n int) time.Duration {
+	return 0
+}
@@ -0,0 +1,26 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// zeroByteReadTest 对管道执行n次零字节read系统调用，绕过os.File对空读的短路
+func zeroByteReadTest(n int) time.Duration {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return 0
+	}
+	defer r.Close()
+	defer w.Close()
+	fd := int(r.Fd())
+	var buf [1]byte
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		_, _ = syscall.Read(fd, buf[:0])
+	}
+	return time.Since(start)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// textInputs 文本处理测试的输入数据，生成后只读共享
+type textInputs struct {
+	logs      []byte
+	mixed     []byte
+	matchRe   *regexp.Regexp
+	extractRe *regexp.Regexp
+	records   []textRecord
+	tmpl      *template.Template
+}
+
+type textRecord struct {
+	Name   string
+	Status int
+	Score  float64
+	Tags   []string
+}
+
+var (
+	textOnce sync.Once
+	textData *textInputs
+)
+
+// getTextInputs 生成日志语料、多语种文本、正则和模板
+func getTextInputs() *textInputs {
+	textOnce.Do(func() {
+		r := rand.New(rand.NewSource(42))
+		in := &textInputs{
+			logs:      corpusJSONLogs(r),
+			matchRe:   regexp.MustCompile(`"level":"(error|warn)".*"msg":"[^"]*timeout`),
+			extractRe: regexp.MustCompile(`"svc":"([\w-]+)","path":"(/v\d/\w+)/(\d+)","status":(\d{3}),"latency_ms":([\d.]+)`),
+			tmpl: template.Must(template.New("report").Parse(
+				`{{range .}}<tr><td>{{.Name}}</td><td>{{.Status}}</td><td>{{printf "%.2f" .Score}}</td><td>{{range $i, $t := .Tags}}{{if $i}},{{end}}{{$t}}{{end}}</td></tr>
+{{end}}`)),
+		}
+		samples := []string{"GoHyperPi benchmark ", "性能测试工具，", "Тест производительности ", "اختبار الأداء ", "ベンチマーク ", "🚀⚡ "}
+		var mixed strings.Builder
+		for mixed.Len() < 64*1024 {
+			mixed.WriteString(samples[r.Intn(len(samples))])
+		}
+		in.mixed = []byte(mixed.String())
+		for i := 0; i < 500; i++ {
+			in.records = append(in.records, textRecord{
+				Name:   "service-" + strconv.Itoa(r.Intn(100)),
+				Status: []int{200, 201, 404, 500}[r.Intn(4)],
+				Score:  r.Float64() * 100,
+				Tags:   []string{"api", "cache", "db", "queue"}[:r.Intn(5)],
+			})
+		}
+		textData = in
+	})
+	return textData
+}
+
+// textSubtests 文本处理的各项子测试，每项处理一遍输入并返回结果防止被优化
+var textSubtests = []struct {
+	name string
+	run  func(in *textInputs) int
+}{
+	{"正则匹配", regexMatchTest},
+	{"正则子匹配提取", regexExtractTest},
+	{"Builder模板拼接", builderTemplateTest},
+	{"Scanner分行", scannerTest},
+	{"UTF-8解码", utf8DecodeTest},
+	{"text/template渲染", textTemplateTest},
+}
+
+// TextProcessingBenchmark 文本处理测试
+type TextProcessingBenchmark struct {
+	*BaseBenchmark
+}
+
+// NewTextProcessingBenchmark 创建文本处理测试实例
+func NewTextProcessingBenchmark() *TextProcessingBenchmark {
+	return &TextProcessingBenchmark{
+		BaseBenchmark: NewBaseBenchmark(
+			"文本处理测试（Text Processing）",
+			"测试正则、模板、分行扫描和UTF-8解码性能",
+			"算法性能",
+			textProcessingTest,
+			20, // 每项子测试处理20遍
+		),
+	}
+}
+
+// Run 分别计时每项子测试，单核耗时为各子测试耗时之和
+func (b *TextProcessingBenchmark) Run(proc, times int) BenchmarkResult {
+	in := getTextInputs()
+	phases := make([]benchmarkPhase, len(textSubtests))
+	for i, subtest := range textSubtests {
+		run := subtest.run
+		phases[i] = benchmarkPhase{subtest.name, func() {
+			for i := 0; i < b.workload; i++ {
+				_ = run(in)
+			}
+		}}
+	}
+	return b.runPhases(proc, times, phases)
+}
+
+func textProcessingTest(passes int) {
+	in := getTextInputs()
+	sum := 0
+	for _, subtest := range textSubtests {
+		for i := 0; i < passes; i++ {
+			sum += subtest.run(in)
+		}
+	}
+	_ = sum
+}
+
+// regexMatchTest 逐行匹配超时类错误日志
+func regexMatchTest(in *textInputs) int {
+	matches := 0
+	for _, line := range bytes.Split(in.logs, []byte{'\n'}) {
+		if in.matchRe.Match(line) {
+			matches++
+		}
+	}
+	return matches
+}
+
+// regexExtractTest 从整段日志中提取服务名、路径、状态码和延迟
+func regexExtractTest(in *textInputs) int {
+	total := 0
+	for _, m := range in.extractRe.FindAllSubmatch(in.logs, -1) {
+		status, _ := strconv.Atoi(string(m[4]))
+		total += status + len(m[1]) + len(m[2])
+	}
+	return total
+}
+
+// builderTemplateTest 使用strings.Builder手工拼接HTML表格
+func builderTemplateTest(in *textInputs) int {
+	var b strings.Builder
+	for _, rec := range in.records {
+		b.WriteString("<tr><td>")
+		b.WriteString(rec.Name)
+		b.WriteString("</td><td>")
+		b.WriteString(strconv.Itoa(rec.Status))
+		b.WriteString("</td><td>")
+		b.WriteString(strconv.FormatFloat(rec.Score, 'f', 2, 64))
+		b.WriteString("</td><td>")
+		b.WriteString(strings.Join(rec.Tags, ","))
+		b.WriteString("</td></tr>\n")
+	}
+	return b.Len()
+}
+
+// scannerTest 使用bufio.Scanner按行和按单词切分日志
+func scannerTest(in *textInputs) int {
+	count := 0
+	lines := bufio.NewScanner(bytes.NewReader(in.logs))
+	for lines.Scan() {
+		count += len(lines.Bytes())
+	}
+	words := bufio.NewScanner(bytes.NewReader(in.mixed))
+	words.Split(bufio.ScanWords)
+	for words.Scan() {
+		count++
+	}
+	return count
+}
+
+// utf8DecodeTest 逐个解码多语种文本中的字符并统计汉字数量
+func utf8DecodeTest(in *textInputs) int {
+	han := 0
+	for data := in.mixed; len(data) > 0; {
+		r, size := utf8.DecodeRune(data)
+		if unicode.Is(unicode.Han, r) {
+			han++
+		}
+		data = data[size:]
+	}
+	return han
+}
+
+// textTemplateTest 使用text/template渲染与builderTemplateTest相同的表格
+func textTemplateTest(in *textInputs) int {
+	var buf bytes.Buffer
+	_ = in.tmpl.Execute(&buf, in.records)
+	return buf.Len()
+}
@@ -4,7 +4,13 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/klauspost/cpuid/v2"
 )
 
 // writeReportToFile 将报告写入文件
@@ -25,16 +31,171 @@ func formatDuration(d float64) string {
 	}
 }
 
-// timeToScore 耗时转为分数
-func timeToScore(duration time.Duration) float64 {
-	// 根据实际测试结果调整系数
-	const k = 40000000.0 // 4000万系数
+// formatBytes 格式化字节数
+func formatBytes(size int) string {
+	switch {
+	case size >= 1024*1024*1024:
+		return fmt.Sprintf("%.1fGB", float64(size)/1024/1024/1024)
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1fMB", float64(size)/1024/1024)
+	case size >= 1024:
+		return fmt.Sprintf("%.1fKB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%dB", size)
+	}
+}
 
-	if duration.Nanoseconds() == 0 {
-		return math.Inf(1) // 如果时间为0，返回无穷大
+// renderBarChart 将指标绘制为对数刻度的文本柱状图
+func renderBarChart(metrics []BenchmarkMetric, width int) []string {
+	if len(metrics) == 0 {
+		return nil
+	}
+	minValue, maxValue := metrics[0].Value, metrics[0].Value
+	for _, metric := range metrics {
+		minValue = math.Min(minValue, metric.Value)
+		maxValue = math.Max(maxValue, metric.Value)
+	}
+	if minValue <= 0 {
+		minValue = 1e-9
+	}
+	lines := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		n := width
+		if maxValue > minValue {
+			n = int(float64(width) * math.Log(1+metric.Value/minValue) / math.Log(1+maxValue/minValue))
+		}
+		if n < 1 {
+			n = 1
+		}
+		lines = append(lines, fmt.Sprintf("%10s | %-*s %.2f %s", metric.Name, width, strings.Repeat("#", n), metric.Value, metric.Unit))
 	}
+	return lines
+}
+
+// percentile 返回样本的p分位数（p取值0~1），会对样本排序
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples[int(p*float64(len(samples)-1)+0.5)]
+}
+
+// latencyMetrics 将延迟样本汇总为平均值和p50/p90/p99指标
+func latencyMetrics(name string, samples []time.Duration) []BenchmarkMetric {
+	if len(samples) == 0 {
+		return nil
+	}
+	var total time.Duration
+	for _, s := range samples {
+		total += s
+	}
+	mean := float64(total.Nanoseconds()) / float64(len(samples))
+	return []BenchmarkMetric{
+		{Name: name + "/avg", Value: mean, Unit: "ns/op"},
+		{Name: name + "/p50", Value: float64(percentile(samples, 0.50).Nanoseconds()), Unit: "ns/op"},
+		{Name: name + "/p90", Value: float64(percentile(samples, 0.90).Nanoseconds()), Unit: "ns/op"},
+		{Name: name + "/p99", Value: float64(percentile(samples, 0.99).Nanoseconds()), Unit: "ns/op"},
+	}
+}
+
+// formatCount 格式化数量，如1K、10M
+func formatCount(n int) string {
+	switch {
+	case n >= 1000000 && n%1000000 == 0:
+		return fmt.Sprintf("%dM", n/1000000)
+	case n >= 1000 && n%1000 == 0:
+		return fmt.Sprintf("%dK", n/1000)
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+// minInt 返回两个整数中较小的一个
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
 
-	// 将纳秒转换为毫秒来计算
-	msElapsed := float64(duration.Nanoseconds()) / 1000000.0
-	return k / msElapsed
+// cacheLineSize 返回缓存行大小，无法识别时使用64字节
+func cacheLineSize() int {
+	if cpuid.CPU.CacheLine >= 8 {
+		return cpuid.CPU.CacheLine
+	}
+	return 64
+}
+
+// displayWidth 返回字符串在终端中的显示宽度，中日韩文字和全角字符按2列计算
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case r >= 0x1100 && r <= 0x115F, // 韩文字母
+			r >= 0x2E80 && r <= 0xA4CF && r != 0x303F, // 中日韩部首、标点、假名和统一汉字
+			r >= 0xAC00 && r <= 0xD7A3,                // 韩文音节
+			r >= 0xF900 && r <= 0xFAFF,                // 兼容汉字
+			r >= 0xFE30 && r <= 0xFE4F,                // 兼容标点
+			r >= 0xFF00 && r <= 0xFF60,                // 全角字符
+			r >= 0xFFE0 && r <= 0xFFE6,                // 全角符号
+			r >= 0x1F300 && r <= 0x1FAFF,              // 表情符号
+			r >= 0x20000 && r <= 0x3FFFD:              // 扩展汉字
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// padRight 在右侧补空格至指定显示宽度，%-Ns按字节数对齐中文时会错位
+func padRight(s string, width int) string {
+	if n := width - displayWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// padLeft 在左侧补空格至指定显示宽度
+func padLeft(s string, width int) string {
+	if n := width - displayWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
+
+var (
+	tempDirsMu sync.Mutex
+	tempDirs   = make(map[string]bool)
+)
+
+// createTempDir 在parent下按pattern创建临时目录并登记，便于退出时清理
+func createTempDir(parent, pattern string) (string, error) {
+	dir, err := os.MkdirTemp(parent, pattern)
+	if err != nil {
+		return "", err
+	}
+	tempDirsMu.Lock()
+	tempDirs[dir] = true
+	tempDirsMu.Unlock()
+	return dir, nil
+}
+
+// removeTempDir 删除临时目录并取消登记
+func removeTempDir(dir string) {
+	_ = os.RemoveAll(dir)
+	tempDirsMu.Lock()
+	delete(tempDirs, dir)
+	tempDirsMu.Unlock()
+}
+
+// cleanupTempDirs 删除所有尚未清理的临时目录，用于程序被中断时
+func cleanupTempDirs() {
+	tempDirsMu.Lock()
+	defer tempDirsMu.Unlock()
+	for dir := range tempDirs {
+		_ = os.RemoveAll(dir)
+		delete(tempDirs, dir)
+	}
 }
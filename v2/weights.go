@@ -0,0 +1,130 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// builtinWeights 内置的权重方案，文件名即方案名
+//
+//go:embed data/weights/*.json
+var builtinWeights embed.FS
+
+// WeightProfile 评分权重方案
+type WeightProfile struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Categories  map[string]float64         `json:"categories"` // 类别权重，总和必须为1
+	Benchmarks  map[string]BenchmarkWeight `json:"benchmarks"` // 单项测试的类别和权重覆盖
+}
+
+// BenchmarkWeight 单项测试在类别内的设置
+type BenchmarkWeight struct {
+	Category string   `json:"category"` // 为空则使用测试自带的类别，可指定自定义类别
+	Weight   *float64 `json:"weight"`   // 类别内的相对权重，为空则为1
+}
+
+// LoadWeightProfile 加载内置方案名或JSON文件路径对应的权重方案
+func LoadWeightProfile(nameOrPath string) (*WeightProfile, error) {
+	data, err := builtinWeights.ReadFile("data/weights/" + nameOrPath + ".json")
+	if err != nil {
+		if data, err = os.ReadFile(nameOrPath); err != nil {
+			return nil, fmt.Errorf("找不到权重方案 %q（内置方案: %s）: %v", nameOrPath, strings.Join(builtinWeightNames(), ", "), err)
+		}
+	}
+	var profile WeightProfile
+	if err = json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("解析权重方案 %s 失败: %v", nameOrPath, err)
+	}
+	if profile.Name == "" {
+		profile.Name = nameOrPath
+	}
+	return &profile, nil
+}
+
+// builtinWeightNames 返回内置方案名
+func builtinWeightNames() []string {
+	entries, _ := builtinWeights.ReadDir("data/weights")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CategoryOf 返回测试在该方案下所属的类别
+func (wp *WeightProfile) CategoryOf(name, category string) string {
+	if bw, ok := wp.Benchmarks[name]; ok && bw.Category != "" {
+		return bw.Category
+	}
+	return category
+}
+
+// BenchmarkWeightOf 返回测试在类别内的相对权重
+func (wp *WeightProfile) BenchmarkWeightOf(name string) float64 {
+	if bw, ok := wp.Benchmarks[name]; ok && bw.Weight != nil {
+		return *bw.Weight
+	}
+	return 1
+}
+
+// Validate 检查权重非负、类别权重总和为1、已注册的每个类别都配置了权重，且权重大于0的类别至少包含一项测试
+// benchmarks应为未经-skip-io和-category过滤的完整测试列表
+func (wp *WeightProfile) Validate(benchmarks []Benchmark) error {
+	sum := 0.0
+	for category, weight := range wp.Categories {
+		if weight < 0 {
+			return fmt.Errorf("类别 %s 的权重为负数", category)
+		}
+		sum += weight
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		return fmt.Errorf("类别权重总和为 %.4f，应为1", sum)
+	}
+	registered := make(map[string]bool)
+	used := make(map[string]bool)
+	for _, benchmark := range benchmarks {
+		registered[benchmark.Name()] = true
+		category := wp.CategoryOf(benchmark.Name(), benchmark.Category())
+		if _, ok := wp.Categories[category]; !ok {
+			return fmt.Errorf("类别 %s（%s）未配置权重", category, benchmark.Name())
+		}
+		used[category] = true
+	}
+	for _, category := range wp.sortedCategories() {
+		if wp.Categories[category] > 0 && !used[category] {
+			return fmt.Errorf("类别 %s 配置了权重但没有任何测试", category)
+		}
+	}
+	for name := range wp.Benchmarks {
+		if !registered[name] {
+			return fmt.Errorf("未知的测试项目 %s", name)
+		}
+		if wp.BenchmarkWeightOf(name) < 0 {
+			return fmt.Errorf("测试项目 %s 的权重为负数", name)
+		}
+	}
+	return nil
+}
+
+// sortedCategories 按名称排序返回配置了权重的类别，保证校验错误信息稳定
+func (wp *WeightProfile) sortedCategories() []string {
+	categories := make([]string, 0, len(wp.Categories))
+	for category := range wp.Categories {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// validateProfile 使用完整的测试列表校验权重方案，不受-skip-io等运行时过滤的影响
+func validateProfile(profile *WeightProfile, opts SuiteOptions) error {
+	opts.SkipStorage = false
+	return profile.Validate(NewBenchmarkSuite(opts).benchmarks)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readmeProfile 从README中提取自定义方案示例并写入临时文件
+func readmeProfile(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "README.md"))
+	if err != nil {
+		t.Fatalf("读取README失败: %v", err)
+	}
+	text := string(data)
+	start := strings.Index(text, "自定义方案示例")
+	if start < 0 {
+		t.Fatal("README中没有自定义方案示例")
+	}
+	text = text[start:]
+	const fence = "```json\n"
+	begin := strings.Index(text, fence)
+	if begin < 0 {
+		t.Fatal("README中的自定义方案示例缺少JSON代码块")
+	}
+	text = text[begin+len(fence):]
+	end := strings.Index(text, "```")
+	if end < 0 {
+		t.Fatal("README中的自定义方案示例代码块未结束")
+	}
+	path := filepath.Join(t.TempDir(), "my-profile.json")
+	if err = os.WriteFile(path, []byte(text[:end]), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBuiltinProfilesValidate(t *testing.T) {
+	for _, name := range builtinWeightNames() {
+		profile, err := LoadWeightProfile(name)
+		if err != nil {
+			t.Fatalf("加载 %s 失败: %v", name, err)
+		}
+		if err = validateProfile(profile, SuiteOptions{}); err != nil {
+			t.Errorf("内置方案 %s 无效: %v", name, err)
+		}
+	}
+}
+
+// TestValidateIgnoresRunFilters README示例引用了存储测试，使用-skip-io时仍应通过校验
+func TestValidateIgnoresRunFilters(t *testing.T) {
+	profile, err := LoadWeightProfile(readmeProfile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := SuiteOptions{SkipStorage: true}
+	if err = profile.Validate(NewBenchmarkSuite(opts).benchmarks); err == nil {
+		t.Fatal("只按过滤后的测试列表校验时应报告未知的测试项目")
+	}
+	if err = validateProfile(profile, opts); err != nil {
+		t.Errorf("使用-skip-io时README示例应通过校验: %v", err)
+	}
+}
+
+func TestValidateCategoryWithoutBenchmarks(t *testing.T) {
+	benchmarks := []Benchmark{
+		NewBaseBenchmark("A", "", "计算密集型", func(int) {}, 1),
+		NewBaseBenchmark("B", "", "内存性能", func(int) {}, 1),
+	}
+	tests := []struct {
+		name       string
+		categories map[string]float64
+		wantErr    string
+	}{
+		{"全部类别都有测试", map[string]float64{"计算密集型": 0.5, "内存性能": 0.5}, ""},
+		{"零权重的空类别", map[string]float64{"计算密集型": 0.5, "内存性能": 0.5, "网络性能": 0}, ""},
+		{"有权重的空类别", map[string]float64{"计算密集型": 0.4, "内存性能": 0.4, "网络性能": 0.2}, "网络性能"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &WeightProfile{Name: tt.name, Categories: tt.categories}
+			err := profile.Validate(benchmarks)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("意外的错误: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("错误 = %v，应包含 %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReportListsCategoriesNotRun(t *testing.T) {
+	profile, err := LoadWeightProfile("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := NewScoreCalculator(profile, false)
+	results := []BenchmarkResult{{Name: "A", Category: "计算密集型", Proc: 1}}
+	missing := sc.missingCategories(results)
+	for _, category := range missing {
+		if category == "计算密集型" {
+			t.Errorf("已运行的类别 %s 不应列为未运行", category)
+		}
+		if profile.Categories[category] <= 0 {
+			t.Errorf("零权重的类别 %s 不应列为未运行", category)
+		}
+	}
+	if len(missing) == 0 {
+		t.Error("只运行一个类别时应列出其余有权重的类别")
+	}
+}